@@ -0,0 +1,33 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"log"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+)
+
+// Validate config before deploy, config is validated on parse, ping checks database connection as well
+func runConfig(cfg *config.Config, args []string) error {
+	if len(args) == 0 || args[0] != "validate" {
+		return errors.New("usage: config validate [-ping]")
+	}
+
+	fs := flag.NewFlagSet("config validate", flag.ContinueOnError)
+	ping := fs.Bool("ping", false, "check database connection")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	if *ping {
+		db, err := connectDB(cfg)
+		if err != nil {
+			return err
+		}
+		db.Close()
+	}
+
+	log.Println("config is valid")
+	return nil
+}
@@ -1,16 +1,42 @@
 package main
 
 import (
+	"fmt"
 	"log"
 	"os"
 
+	"github.com/jmoiron/sqlx"
+
 	"github.com/AliIsmoilov/golang_monolight/config"
-	"github.com/AliIsmoilov/golang_monolight/internal/server"
 	"github.com/AliIsmoilov/golang_monolight/pkg/db/postgres"
 	"github.com/AliIsmoilov/golang_monolight/pkg/logger"
 	"github.com/AliIsmoilov/golang_monolight/pkg/utils"
 )
 
+const usage = `usage: app <command> [arguments]
+
+commands:
+  serve                          run api server, default command
+  migrate up|down [N]|status|force VERSION
+                                 run embedded database migrations
+  seed -file FILE -author EMAIL  load news and blogs fixtures from json or yaml file
+  user create -email EMAIL -role ROLE [-first-name NAME] [-last-name NAME] [-password PASSWORD]
+                                 create user, password is read from stdin when not given
+  config validate [-ping]        validate config, ping checks database connection
+
+config file is chosen by "config" environment variable, local or docker`
+
+// Command runs with parsed config and its own arguments
+type command func(cfg *config.Config, args []string) error
+
+var commands = map[string]command{
+	"serve":   runServe,
+	"migrate": runMigrate,
+	"seed":    runSeed,
+	"user":    runUser,
+	"config":  runConfig,
+}
+
 // @title Go app
 // @version 1.0
 // @description Golang app
@@ -19,35 +45,57 @@ import (
 // @contact.email alimadadismoilov@gmail.com
 // @BasePath /api/v1
 func main() {
-	log.Println("Starting api server")
+	name, args := "serve", os.Args[1:]
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
+	}
+	if name == "help" || name == "-h" || name == "--help" {
+		fmt.Println(usage)
+		return
+	}
+
+	cmd, ok := commands[name]
+	if !ok {
+		log.Fatalf("unknown command %q\n%s", name, usage)
+	}
 
+	cfg, err := loadConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err = cmd(cfg, args); err != nil {
+		log.Fatalf("%s: %v", name, err)
+	}
+}
+
+// Load and parse config chosen by "config" environment variable
+func loadConfig() (*config.Config, error) {
 	configPath := utils.GetConfigPath(os.Getenv("config"))
 
 	cfgFile, err := config.LoadConfig(configPath)
 	if err != nil {
-		log.Fatalf("LoadConfig: %v", err)
+		return nil, fmt.Errorf("LoadConfig: %w", err)
 	}
 
 	cfg, err := config.ParseConfig(cfgFile)
 	if err != nil {
-		log.Fatalf("ParseConfig: %v", err)
+		return nil, fmt.Errorf("ParseConfig: %w", err)
 	}
 
-	appLogger := logger.NewApiLogger(cfg)
+	return cfg, nil
+}
 
+func newLogger(cfg *config.Config) logger.Logger {
+	appLogger := logger.NewApiLogger(cfg)
 	appLogger.InitLogger()
-	appLogger.Infof("AppVersion: %s, LogLevel: %s, Mode: %s", cfg.Server.AppVersion, cfg.Logger.Level, cfg.Server.Mode)
+	return appLogger
+}
 
+func connectDB(cfg *config.Config) (*sqlx.DB, error) {
 	psqlDB, err := postgres.NewPsqlDB(cfg)
 	if err != nil {
-		appLogger.Fatalf("Postgresql init: %s", err)
-	} else {
-		appLogger.Infof("Postgres connected, Status: %#v", psqlDB.Stats())
-	}
-	defer psqlDB.Close()
-
-	s := server.NewServer(cfg, psqlDB, appLogger)
-	if err = s.Run(); err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("Postgresql init: %w", err)
 	}
+	return psqlDB, nil
 }
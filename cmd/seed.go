@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+	authRepository "github.com/AliIsmoilov/golang_monolight/internal/auth/repository"
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+	todosRepository "github.com/AliIsmoilov/golang_monolight/internal/todos/repository"
+	"github.com/AliIsmoilov/golang_monolight/pkg/utils"
+)
+
+// Seed fixtures file, json is parsed as yaml
+type seedFixtures struct {
+	News  []seedNews `yaml:"news"`
+	Blogs []seedBlog `yaml:"blogs"`
+}
+
+// Author is user email, default author is used when empty
+type seedNews struct {
+	Title       string `yaml:"title"`
+	Description string `yaml:"description"`
+	Author      string `yaml:"author"`
+}
+
+type seedBlog struct {
+	Title  string `yaml:"title"`
+	Author string `yaml:"author"`
+}
+
+// Load news and blogs fixtures, authors must already exist
+func runSeed(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	file := fs.String("file", "", "fixtures json or yaml file")
+	author := fs.String("author", "", "default author email")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("usage: seed -file FILE [-author EMAIL]")
+	}
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		return err
+	}
+	fixtures := &seedFixtures{}
+	if err = yaml.Unmarshal(data, fixtures); err != nil {
+		return fmt.Errorf("parse %s: %w", *file, err)
+	}
+
+	db, err := connectDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	authRepo := authRepository.NewAuthRepository(db)
+	newsRepo := todosRepository.NewNewsRepository(db)
+	blogsRepo := todosRepository.NewToDosRepository(db)
+
+	authors := make(map[string]uuid.UUID)
+	authorID := func(email string) (uuid.UUID, error) {
+		if email == "" {
+			email = *author
+		}
+		email = strings.ToLower(strings.TrimSpace(email))
+		if email == "" {
+			return uuid.Nil, errors.New("author is not set, use -author flag or author field")
+		}
+		if id, ok := authors[email]; ok {
+			return id, nil
+		}
+
+		user, err := authRepo.FindByEmail(ctx, email)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("author %s: %w", email, err)
+		}
+		if !user.CanPublish() {
+			return uuid.Nil, fmt.Errorf("author %s with role %s may not publish", email, user.Role)
+		}
+		authors[email] = user.ID
+		return user.ID, nil
+	}
+
+	// Validate all fixtures before insert, so broken file does not leave partial seed
+	news := make([]*models.News, 0, len(fixtures.News))
+	for i, n := range fixtures.News {
+		publishedBy, err := authorID(n.Author)
+		if err != nil {
+			return fmt.Errorf("news %d: %w", i, err)
+		}
+		item := &models.News{Title: n.Title, Description: n.Description, PublishedBy: publishedBy}
+		if err = utils.ValidateStruct(ctx, item); err != nil {
+			return fmt.Errorf("news %d: %w", i, err)
+		}
+		news = append(news, item)
+	}
+
+	blogs := make([]*models.Blog, 0, len(fixtures.Blogs))
+	for i, b := range fixtures.Blogs {
+		publishedBy, err := authorID(b.Author)
+		if err != nil {
+			return fmt.Errorf("blog %d: %w", i, err)
+		}
+		item := &models.Blog{Title: b.Title, PublishedBy: publishedBy}
+		if err = utils.ValidateStruct(ctx, item); err != nil {
+			return fmt.Errorf("blog %d: %w", i, err)
+		}
+		blogs = append(blogs, item)
+	}
+
+	for i, item := range news {
+		if _, err = newsRepo.Create(ctx, item); err != nil {
+			return fmt.Errorf("news %d: %w", i, err)
+		}
+	}
+	for i, item := range blogs {
+		if _, err = blogsRepo.Create(ctx, item); err != nil {
+			return fmt.Errorf("blog %d: %w", i, err)
+		}
+	}
+
+	log.Printf("seeded %d news and %d blogs", len(fixtures.News), len(fixtures.Blogs))
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/internal/server"
+	"github.com/AliIsmoilov/golang_monolight/pkg/tracing"
+	"github.com/AliIsmoilov/golang_monolight/pkg/utils"
+)
+
+// Run api server until interrupted, safe settings are reloaded on config file change
+func runServe(cfg *config.Config, _ []string) error {
+	appLogger := newLogger(cfg)
+	appLogger.Info("Starting api server")
+	appLogger.Infof("AppVersion: %s, LogLevel: %s, Mode: %s", cfg.Server.AppVersion, cfg.Logger.Level, cfg.Server.Mode)
+
+	psqlDB, err := connectDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer psqlDB.Close()
+	appLogger.Infof("Postgres connected, Status: %#v", psqlDB.Stats())
+
+	tracerProvider, err := tracing.NewProvider(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := tracerProvider.Shutdown(context.Background()); err != nil {
+			appLogger.Errorf("Tracer provider shutdown: %v", err)
+		}
+	}()
+
+	s := server.NewServer(cfg, psqlDB, appLogger)
+
+	err = config.Watch(utils.GetConfigPath(os.Getenv("config")), func(newCfg *config.Config, err error) {
+		if err != nil {
+			appLogger.Errorf("Config reload, keeping previous settings: %v", err)
+			return
+		}
+		s.Reload(newCfg)
+	})
+	if err != nil {
+		appLogger.Errorf("Config watch: %v", err)
+	}
+
+	return s.Run()
+}
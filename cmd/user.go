@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+	authRepository "github.com/AliIsmoilov/golang_monolight/internal/auth/repository"
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+	"github.com/AliIsmoilov/golang_monolight/pkg/utils"
+)
+
+const userUsage = "usage: user create -email EMAIL -role ROLE [-first-name NAME] [-last-name NAME] [-password PASSWORD]"
+
+// Manage users, e.g. create first admin who grants roles to others
+func runUser(cfg *config.Config, args []string) error {
+	if len(args) == 0 || args[0] != "create" {
+		return errors.New(userUsage)
+	}
+
+	fs := flag.NewFlagSet("user create", flag.ContinueOnError)
+	email := fs.String("email", "", "user email")
+	role := fs.String("role", models.RoleReader, "admin, editor, author or reader")
+	firstName := fs.String("first-name", "Admin", "user first name")
+	lastName := fs.String("last-name", "User", "user last name")
+	password := fs.String("password", "", "user password, read from stdin when empty")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *email == "" {
+		return errors.New(userUsage)
+	}
+	if !models.IsValidRole(*role) {
+		return fmt.Errorf("unknown role %q", *role)
+	}
+
+	// Password flag is visible in process list, stdin is preferred
+	if *password == "" {
+		fmt.Fprint(os.Stderr, "Password: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return fmt.Errorf("read password: %w", err)
+		}
+		*password = strings.TrimSpace(line)
+	}
+
+	*password = strings.TrimSpace(*password)
+	if utf8.RuneCountInString(*password) < models.MinPasswordLength {
+		return fmt.Errorf("password must be at least %d characters", models.MinPasswordLength)
+	}
+
+	user := &models.User{
+		FirstName: *firstName,
+		LastName:  *lastName,
+		Email:     *email,
+		Password:  *password,
+		Role:      *role,
+	}
+	ctx := context.Background()
+	if err := utils.ValidateStruct(ctx, user); err != nil {
+		return err
+	}
+	if err := user.PrepareCreate(); err != nil {
+		return err
+	}
+
+	db, err := connectDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	authRepo := authRepository.NewAuthRepository(db)
+	if _, err = authRepo.FindByEmail(ctx, user.Email); err == nil {
+		return fmt.Errorf("user %s already exists", user.Email)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	createdUser, err := authRepo.Register(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("created %s %s with id %s", createdUser.Role, createdUser.Email, createdUser.ID)
+	return nil
+}
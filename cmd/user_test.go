@@ -0,0 +1,19 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+)
+
+func TestRunUser_Password(t *testing.T) {
+	t.Parallel()
+
+	// Rejected before database is touched, so empty config is enough
+	for _, password := range []string{"      ", "short"} {
+		err := runUser(&config.Config{}, []string{"create", "-email", "admin@example.com", "-role", "admin", "-password", password})
+		require.ErrorContains(t, err, "password must be at least 6 characters")
+	}
+}
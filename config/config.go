@@ -2,32 +2,54 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"log"
 	"time"
 
 	"github.com/spf13/viper"
 )
 
+// Server modes, secure defaults are enforced outside development
+const (
+	ModeDevelopment = "Development"
+	ModeProduction  = "Production"
+)
+
 // App config struct
 type Config struct {
-	Server   ServerConfig
-	Postgres PostgresConfig
-	Logger   Logger
+	Server    ServerConfig
+	Postgres  PostgresConfig
+	Logger    Logger
+	Media     MediaConfig
+	CORS      CORSConfig
+	Tracing   TracingConfig
+	RateLimit RateLimitConfig
 }
 
 // Server config struct
 type ServerConfig struct {
-	AppVersion        string
-	Port              string
-	PprofPort         string
-	Mode              string
-	JwtSecretKey      string
-	CookieName        string
-	ReadTimeout       time.Duration
-	WriteTimeout      time.Duration
-	CtxDefaultTimeout time.Duration
-	CSRF              bool
-	Debug             bool
+	AppVersion         string
+	Port               string
+	PprofPort          string
+	Mode               string
+	JwtSecretKey       string
+	CookieName         string
+	AccessTokenExpire  time.Duration
+	RefreshTokenExpire time.Duration
+	ReadTimeout        time.Duration
+	WriteTimeout       time.Duration
+	CtxDefaultTimeout  time.Duration
+	ShutdownTimeout    time.Duration
+	TrustedProxies     []string
+	CSRF               bool
+	CSRFSalt           string
+	CSRFExpire         time.Duration
+	TrashRetention     time.Duration
+	TrashPurgeInterval time.Duration
+	// Readiness probe
+	ReadinessTimeout    time.Duration
+	ReadinessDrainDelay time.Duration
+	Debug               bool
 }
 
 // Logger config
@@ -39,6 +61,58 @@ type Logger struct {
 	Level             string
 }
 
+// CORS config, origins are reloaded without restart, other fields need restart.
+// Credentials are allowed only for listed origins, never for "*". MaxAge is in seconds.
+type CORSConfig struct {
+	AllowOrigins     []string
+	AllowMethods     []string
+	AllowHeaders     []string
+	ExposeHeaders    []string
+	AllowCredentials bool
+	MaxAge           int
+}
+
+// Rate limit config, Store is memory or redis, token bucket allows Requests per Period seconds
+type RateLimitConfig struct {
+	Enabled       bool
+	Store         string
+	ReadRequests  int
+	WriteRequests int
+	Period        time.Duration
+	RedisAddr     string
+	RedisPassword string
+	RedisDB       int
+}
+
+// Tracing config, Exporter is none, stdout, file or otlp
+type TracingConfig struct {
+	Exporter     string
+	ServiceName  string
+	SampleRatio  float64
+	FilePath     string
+	OTLPEndpoint string
+	OTLPInsecure bool
+}
+
+// Media storage config, Storage is local or s3
+type MediaConfig struct {
+	Storage       string
+	MaxUploadSize int64
+	LocalDir      string
+	S3Endpoint    string
+	S3AccessKey   string
+	S3SecretKey   string
+	S3Bucket      string
+	S3Region      string
+	S3UseSSL      bool
+	PublicURL     string
+	// Variants generation
+	VariantsWorkers       int
+	VariantsQueueSize     int
+	VariantsSweepInterval time.Duration
+	VariantsStaleAfter    time.Duration
+}
+
 // Postgresql config
 type PostgresConfig struct {
 	PostgresqlHost     string
@@ -56,6 +130,7 @@ func LoadConfig(filename string) (*viper.Viper, error) {
 
 	v.SetConfigName(filename)
 	v.AddConfigPath(".")
+	v.SetEnvKeyReplacer(envKeyReplacer)
 	v.AutomaticEnv()
 	if err := v.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
@@ -64,18 +139,29 @@ func LoadConfig(filename string) (*viper.Viper, error) {
 		return nil, err
 	}
 
+	if err := bindEnvs(v); err != nil {
+		return nil, err
+	}
+	if err := readSecretFiles(v); err != nil {
+		return nil, err
+	}
+
 	return v, nil
 }
 
-// Parse config file
+// Parse and validate config file
 func ParseConfig(v *viper.Viper) (*Config, error) {
 	var c Config
 
-	err := v.Unmarshal(&c)
+	err := v.Unmarshal(&c, decodeHook)
 	if err != nil {
 		log.Printf("unable to decode into struct, %v", err)
 		return nil, err
 	}
 
+	if err = c.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
 	return &c, nil
-}
\ No newline at end of file
+}
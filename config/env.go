@@ -0,0 +1,86 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/viper"
+)
+
+// Nested config keys are read from environment with dots replaced by underscores,
+// e.g. postgres.PostgresqlPassword is overridden by POSTGRES_POSTGRESQLPASSWORD
+var envKeyReplacer = strings.NewReplacer(".", "_")
+
+// Environment variable name for config key
+func EnvName(key string) string {
+	return strings.ToUpper(envKeyReplacer.Replace(key))
+}
+
+// Bind every Config field to environment, so keys missing in config file may be set from environment as well
+func bindEnvs(v *viper.Viper) error {
+	for _, key := range configKeys(reflect.TypeOf(Config{}), "") {
+		if err := v.BindEnv(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Secrets from files, <NAME>_FILE variable holds path to file with value of <NAME>
+// and takes precedence over <NAME>, e.g. SERVER_JWTSECRETKEY_FILE=/run/secrets/jwt
+func readSecretFiles(v *viper.Viper) error {
+	for _, key := range configKeys(reflect.TypeOf(Config{}), "") {
+		path, ok := os.LookupEnv(EnvName(key) + "_FILE")
+		if !ok || path == "" {
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read %s_FILE: %w", EnvName(key), err)
+		}
+		v.Set(key, strings.TrimRight(string(data), "\r\n"))
+	}
+	return nil
+}
+
+func configKeys(t reflect.Type, prefix string) []string {
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := strings.ToLower(field.Name)
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		if field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeOf(time.Time{}) {
+			keys = append(keys, configKeys(field.Type, key)...)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Durations are plain numbers multiplied by unit where used, environment gives them as strings
+func numberDurationHook(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if from.Kind() != reflect.String || to != reflect.TypeOf(time.Duration(0)) {
+		return data, nil
+	}
+
+	n, err := strconv.ParseInt(strings.TrimSpace(data.(string)), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("duration %q must be a number", data)
+	}
+	return time.Duration(n), nil
+}
+
+var decodeHook = viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+	numberDurationHook,
+	mapstructure.StringToSliceHookFunc(","),
+))
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig_Env(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "jwt")
+	require.NoError(t, os.WriteFile(secretFile, []byte("file-secret\n"), 0o600))
+
+	t.Setenv("POSTGRES_POSTGRESQLPASSWORD", "env-password")
+	t.Setenv("SERVER_READTIMEOUT", "30")
+	t.Setenv("SERVER_JWTSECRETKEY", "env-secret")
+	t.Setenv("SERVER_JWTSECRETKEY_FILE", secretFile)
+
+	v, err := LoadConfig("config-local")
+	require.NoError(t, err)
+	cfg, err := ParseConfig(v)
+	require.NoError(t, err)
+
+	require.Equal(t, "env-password", cfg.Postgres.PostgresqlPassword)
+	require.Equal(t, time.Duration(30), cfg.Server.ReadTimeout)
+	require.Equal(t, "file-secret", cfg.Server.JwtSecretKey)
+}
+
+func TestParseConfig_DefaultSecret(t *testing.T) {
+	t.Setenv("SERVER_MODE", ModeProduction)
+
+	v, err := LoadConfig("config-local")
+	require.NoError(t, err)
+	_, err = ParseConfig(v)
+	require.ErrorContains(t, err, "server.JwtSecretKey must not be default")
+
+	t.Setenv("SERVER_JWTSECRETKEY", "production-secret")
+
+	v, err = LoadConfig("config-local")
+	require.NoError(t, err)
+	_, err = ParseConfig(v)
+	require.NoError(t, err)
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Runtime holds safe settings which are reloaded from config file without restart
+type Runtime struct {
+	ctxDefaultTimeout atomic.Int64
+	corsOrigins       atomic.Pointer[[]string]
+	logLevel          atomic.Pointer[string]
+}
+
+// Runtime settings constructor
+func NewRuntime(c *Config) *Runtime {
+	r := &Runtime{}
+	r.Update(c)
+	return r
+}
+
+// Update applies safe settings from reloaded config
+func (r *Runtime) Update(c *Config) {
+	r.ctxDefaultTimeout.Store(int64(c.Server.CtxDefaultTimeout))
+	origins := append([]string(nil), c.CORS.AllowOrigins...)
+	r.corsOrigins.Store(&origins)
+	level := c.Logger.Level
+	r.logLevel.Store(&level)
+}
+
+// Request context timeout in seconds
+func (r *Runtime) CtxDefaultTimeout() time.Duration {
+	return time.Duration(r.ctxDefaultTimeout.Load())
+}
+
+// Allowed CORS origins, "*" allows any origin
+func (r *Runtime) CORSOrigins() []string {
+	return *r.corsOrigins.Load()
+}
+
+// Logger level from config file, actual level may be changed by admin api
+func (r *Runtime) LogLevel() string {
+	return *r.logLevel.Load()
+}
+
+// Watch config file, onChange gets parsed config or parse error on every file change
+func Watch(filename string, onChange func(c *Config, err error)) error {
+	v, err := LoadConfig(filename)
+	if err != nil {
+		return err
+	}
+
+	v.OnConfigChange(func(fsnotify.Event) {
+		onChange(ParseConfig(v))
+	})
+	v.WatchConfig()
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuntime_Update(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{}
+	cfg.Server.CtxDefaultTimeout = 12
+	cfg.CORS.AllowOrigins = []string{"https://example.com"}
+	cfg.Logger.Level = "info"
+
+	r := NewRuntime(cfg)
+	require.Equal(t, time.Duration(12), r.CtxDefaultTimeout())
+	require.Equal(t, []string{"https://example.com"}, r.CORSOrigins())
+
+	// Runtime keeps its own copy, reload replaces settings at once
+	cfg.CORS.AllowOrigins[0] = "https://changed.com"
+	require.Equal(t, []string{"https://example.com"}, r.CORSOrigins())
+
+	r.Update(&Config{Server: ServerConfig{CtxDefaultTimeout: 30}, Logger: Logger{Level: "debug"}})
+	require.Equal(t, time.Duration(30), r.CtxDefaultTimeout())
+	require.Empty(t, r.CORSOrigins())
+	require.Equal(t, "debug", r.LogLevel())
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// Sample secret from config-local.yml, must not be used outside development
+const defaultJwtSecretKey = "secretkey"
+
+var validLoggerLevels = map[string]bool{
+	"debug": true, "info": true, "warn": true, "error": true, "dpanic": true, "panic": true, "fatal": true,
+}
+
+// Validate checks required config values, returns all found problems
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.Server.Port != "", "server.Port is required")
+	check(c.Server.JwtSecretKey != "", "server.JwtSecretKey is required")
+	check(c.Server.Mode == ModeDevelopment || c.Server.JwtSecretKey != defaultJwtSecretKey,
+		"server.JwtSecretKey must not be default outside %s mode, set %s or %s_FILE",
+		ModeDevelopment, EnvName("server.JwtSecretKey"), EnvName("server.JwtSecretKey"))
+	check(c.Server.CookieName != "", "server.CookieName is required")
+	check(c.Server.AccessTokenExpire > 0, "server.AccessTokenExpire must be positive")
+	check(c.Server.RefreshTokenExpire > 0, "server.RefreshTokenExpire must be positive")
+	check(c.Server.ReadTimeout > 0, "server.ReadTimeout must be positive")
+	check(c.Server.WriteTimeout > 0, "server.WriteTimeout must be positive")
+	check(c.Server.ShutdownTimeout >= 0, "server.ShutdownTimeout must not be negative")
+	for _, proxy := range c.Server.TrustedProxies {
+		check(validProxy(proxy), "server.TrustedProxies %q must be ip or CIDR", proxy)
+	}
+	check(c.Server.ReadinessTimeout > 0, "server.ReadinessTimeout must be positive")
+	check(c.Server.ReadinessDrainDelay >= 0, "server.ReadinessDrainDelay must not be negative")
+	check(!c.Server.CSRF || c.Server.CSRFSalt != "", "server.CSRFSalt is required when CSRF is enabled")
+
+	check(c.Postgres.PostgresqlHost != "", "postgres.PostgresqlHost is required")
+	check(c.Postgres.PostgresqlPort != "", "postgres.PostgresqlPort is required")
+	check(c.Postgres.PostgresqlUser != "", "postgres.PostgresqlUser is required")
+	check(c.Postgres.PostgresqlDbname != "", "postgres.PostgresqlDbname is required")
+	check(c.Postgres.PgDriver != "", "postgres.PgDriver is required")
+
+	check(c.Logger.Level == "" || validLoggerLevels[c.Logger.Level], "logger.Level %q is unknown", c.Logger.Level)
+	check(c.Logger.Encoding == "" || c.Logger.Encoding == "json" || c.Logger.Encoding == "console",
+		"logger.Encoding %q must be json or console", c.Logger.Encoding)
+
+	for _, origin := range c.CORS.AllowOrigins {
+		check(validOrigin(origin), "cors.AllowOrigins %q must be \"*\" or scheme://host[:port]", origin)
+	}
+	check(c.CORS.MaxAge >= 0, "cors.MaxAge must not be negative")
+
+	switch c.Tracing.Exporter {
+	case "", "none", "stdout":
+	case "file":
+		check(c.Tracing.FilePath != "", "tracing.FilePath is required for file exporter")
+	case "otlp":
+		check(c.Tracing.OTLPEndpoint != "", "tracing.OTLPEndpoint is required for otlp exporter")
+	default:
+		check(false, "tracing.Exporter %q must be none, stdout, file or otlp", c.Tracing.Exporter)
+	}
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.SampleRatio must be between 0 and 1")
+
+	if c.RateLimit.Enabled {
+		check(c.RateLimit.ReadRequests > 0, "rateLimit.ReadRequests must be positive")
+		check(c.RateLimit.WriteRequests > 0, "rateLimit.WriteRequests must be positive")
+		check(c.RateLimit.Period > 0, "rateLimit.Period must be positive")
+		switch c.RateLimit.Store {
+		case "", "memory":
+		case "redis":
+			check(c.RateLimit.RedisAddr != "", "rateLimit.RedisAddr is required for redis store")
+		default:
+			check(false, "rateLimit.Store %q must be memory or redis", c.RateLimit.Store)
+		}
+	}
+
+	check(c.Media.MaxUploadSize > 0, "media.MaxUploadSize must be positive")
+	check(c.Media.VariantsStaleAfter > 0, "media.VariantsStaleAfter must be positive")
+	switch c.Media.Storage {
+	case "", "local":
+		check(c.Media.LocalDir != "", "media.LocalDir is required for local storage")
+	case "s3":
+		check(c.Media.S3Endpoint != "", "media.S3Endpoint is required for s3 storage")
+		check(c.Media.S3Bucket != "", "media.S3Bucket is required for s3 storage")
+		check(c.Media.S3AccessKey != "" && c.Media.S3SecretKey != "", "media.S3AccessKey and S3SecretKey are required for s3 storage")
+	default:
+		check(false, "media.Storage %q must be local or s3", c.Media.Storage)
+	}
+
+	return errors.Join(errs...)
+}
+
+func validProxy(proxy string) bool {
+	proxy = strings.TrimSpace(proxy)
+	if strings.Contains(proxy, "/") {
+		_, _, err := net.ParseCIDR(proxy)
+		return err == nil
+	}
+	return net.ParseIP(proxy) != nil
+}
+
+func validOrigin(origin string) bool {
+	if origin == "*" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && u.Scheme != "" && u.Host != "" && u.Path == "" && u.RawQuery == ""
+}
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	v, err := LoadConfig("config-local")
+	require.NoError(t, err)
+	cfg, err := ParseConfig(v)
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	cfg.Server.Port = ""
+	cfg.Media.Storage = "ftp"
+	err = cfg.Validate()
+	require.ErrorContains(t, err, "server.Port is required")
+	require.ErrorContains(t, err, `media.Storage "ftp" must be local or s3`)
+}
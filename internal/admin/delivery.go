@@ -0,0 +1,9 @@
+package admin
+
+import "github.com/labstack/echo/v4"
+
+// Admin HTTP Handlers interface
+type Handlers interface {
+	GetLogLevel() echo.HandlerFunc
+	SetLogLevel() echo.HandlerFunc
+}
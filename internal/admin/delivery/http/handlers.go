@@ -0,0 +1,79 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/internal/admin"
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+	"github.com/AliIsmoilov/golang_monolight/pkg/httpErrors"
+	"github.com/AliIsmoilov/golang_monolight/pkg/logger"
+	"github.com/AliIsmoilov/golang_monolight/pkg/utils"
+)
+
+// Admin handlers
+type adminHandlers struct {
+	cfg     *config.Config
+	adminUC admin.UseCase
+	logger  logger.Logger
+}
+
+// Admin handlers constructor
+func NewAdminHandlers(cfg *config.Config, adminUC admin.UseCase, logger logger.Logger) admin.Handlers {
+	return &adminHandlers{cfg: cfg, adminUC: adminUC, logger: logger}
+}
+
+// GetLogLevel
+// @Summary Get logger level
+// @Description get current logger level, admins only
+// @Tags Admin
+// @Produce  json
+// @Success 200 {object} models.LogLevel
+// @Failure 401 {object} httpErrors.RestErr
+// @Failure 403 {object} httpErrors.RestErr
+// @Router /admin/log-level [get]
+func (h *adminHandlers) GetLogLevel() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		level, err := h.adminUC.GetLogLevel(c.Request().Context())
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, level)
+	}
+}
+
+// SetLogLevel
+// @Summary Change logger level
+// @Description change logger level without restart, config file change resets it, admins only
+// @Tags Admin
+// @Accept  json
+// @Produce  json
+// @Param body body models.LogLevel true "body"
+// @Success 200 {object} models.LogLevel
+// @Failure 400 {object} httpErrors.RestErr
+// @Failure 401 {object} httpErrors.RestErr
+// @Failure 403 {object} httpErrors.RestErr
+// @Router /admin/log-level [put]
+func (h *adminHandlers) SetLogLevel() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		level := &models.LogLevel{}
+		if err := utils.ReadRequest(c, level); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		updatedLevel, err := h.adminUC.SetLogLevel(c.Request().Context(), level)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, updatedLevel)
+	}
+}
@@ -0,0 +1,14 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/AliIsmoilov/golang_monolight/internal/admin"
+	"github.com/AliIsmoilov/golang_monolight/internal/middleware"
+)
+
+// Map admin routes
+func MapAdminRoutes(adminGroup *echo.Group, h admin.Handlers, mw *middleware.MiddlewareManager) {
+	adminGroup.GET("/log-level", h.GetLogLevel(), mw.AuthJWTMiddleware)
+	adminGroup.PUT("/log-level", h.SetLogLevel(), mw.AuthJWTMiddleware, mw.CSRF)
+}
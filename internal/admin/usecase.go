@@ -0,0 +1,13 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+)
+
+// Admin use case
+type UseCase interface {
+	GetLogLevel(ctx context.Context) (*models.LogLevel, error)
+	SetLogLevel(ctx context.Context, level *models.LogLevel) (*models.LogLevel, error)
+}
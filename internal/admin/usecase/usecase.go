@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/internal/admin"
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+	"github.com/AliIsmoilov/golang_monolight/pkg/httpErrors"
+	"github.com/AliIsmoilov/golang_monolight/pkg/logger"
+	"github.com/AliIsmoilov/golang_monolight/pkg/utils"
+)
+
+// Admin UseCase
+type adminUC struct {
+	cfg    *config.Config
+	logger logger.Logger
+}
+
+// Admin UseCase constructor
+func NewAdminUseCase(cfg *config.Config, logger logger.Logger) admin.UseCase {
+	return &adminUC{cfg: cfg, logger: logger}
+}
+
+// Get current logger level, admins only
+func (u *adminUC) GetLogLevel(ctx context.Context) (*models.LogLevel, error) {
+	if err := checkIsAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	return &models.LogLevel{Level: u.logger.Level()}, nil
+}
+
+// Change logger level until restart or config file change, admins only
+func (u *adminUC) SetLogLevel(ctx context.Context, level *models.LogLevel) (*models.LogLevel, error) {
+	if err := checkIsAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := u.logger.SetLevel(level.Level); err != nil {
+		return nil, httpErrors.NewBadRequestError(err)
+	}
+	u.logger.FromContext(ctx).Warnf("Logger level changed to %s", level.Level)
+
+	return &models.LogLevel{Level: u.logger.Level()}, nil
+}
+
+func checkIsAdmin(ctx context.Context) error {
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return httpErrors.NewUnauthorizedError(err)
+	}
+	if !user.HasRole(models.RoleAdmin) {
+		return httpErrors.NewForbiddenError(httpErrors.PermissionDenied)
+	}
+	return nil
+}
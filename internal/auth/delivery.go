@@ -0,0 +1,13 @@
+package auth
+
+import "github.com/labstack/echo/v4"
+
+// Auth HTTP Handlers interface
+type Handlers interface {
+	Register() echo.HandlerFunc
+	Login() echo.HandlerFunc
+	Logout() echo.HandlerFunc
+	Refresh() echo.HandlerFunc
+	GetMe() echo.HandlerFunc
+	GetCSRFToken() echo.HandlerFunc
+}
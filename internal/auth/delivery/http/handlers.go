@@ -0,0 +1,207 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/internal/auth"
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+	"github.com/AliIsmoilov/golang_monolight/pkg/csrf"
+	"github.com/AliIsmoilov/golang_monolight/pkg/httpErrors"
+	"github.com/AliIsmoilov/golang_monolight/pkg/logger"
+	"github.com/AliIsmoilov/golang_monolight/pkg/utils"
+)
+
+// Auth handlers
+type authHandlers struct {
+	cfg    *config.Config
+	authUC auth.UseCase
+	logger logger.Logger
+}
+
+// NewAuthHandlers Auth handlers constructor
+func NewAuthHandlers(cfg *config.Config, authUC auth.UseCase, logger logger.Logger) auth.Handlers {
+	return &authHandlers{cfg: cfg, authUC: authUC, logger: logger}
+}
+
+// Register
+// @Summary Register new user
+// @Description register new user, returns user, access and refresh tokens
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @Param body body models.UserSwagger true "body"
+// @Success 201 {object} models.UserWithToken
+// @Failure 400 {object} httpErrors.RestErr
+// @Failure 500 {object} httpErrors.RestErr
+// @Router /auth/register [post]
+func (h *authHandlers) Register() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		request := &models.UserSwagger{}
+		if err := c.Bind(request); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+		// Trimmed before validation, so blank password does not pass gte=6
+		request.Trim()
+		if err := utils.ValidateStruct(c.Request().Context(), request); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		userWithToken, err := h.authUC.Register(c.Request().Context(), request.ToUser())
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		c.SetCookie(utils.CreateJWTCookie(h.cfg, userWithToken.AccessToken, userWithToken.ExpiresAt))
+
+		return c.JSON(http.StatusCreated, userWithToken)
+	}
+}
+
+// Login
+// @Summary Login user
+// @Description login user, returns user, access and refresh tokens and sets access token cookie
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @Param body body models.LoginSwagger true "body"
+// @Success 200 {object} models.UserWithToken
+// @Failure 401 {object} httpErrors.RestErr
+// @Failure 500 {object} httpErrors.RestErr
+// @Router /auth/login [post]
+func (h *authHandlers) Login() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		login := &models.LoginSwagger{}
+		if err := utils.ReadRequest(c, login); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		userWithToken, err := h.authUC.Login(c.Request().Context(), &models.User{
+			Email:    login.Email,
+			Password: login.Password,
+		})
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		c.SetCookie(utils.CreateJWTCookie(h.cfg, userWithToken.AccessToken, userWithToken.ExpiresAt))
+
+		return c.JSON(http.StatusOK, userWithToken)
+	}
+}
+
+// Logout
+// @Summary Logout user
+// @Description logout user, deletes current session and access token cookie
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @Success 200 {string} string	"ok"
+// @Failure 401 {object} httpErrors.RestErr
+// @Failure 500 {object} httpErrors.RestErr
+// @Router /auth/logout [post]
+func (h *authHandlers) Logout() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		sid, ok := c.Get("sid").(string)
+		if !ok {
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		sessionID, err := uuid.Parse(sid)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.InvalidJWTClaims))
+		}
+
+		if err = h.authUC.Logout(c.Request().Context(), sessionID); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		c.SetCookie(utils.DeleteJWTCookie(h.cfg))
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+// Refresh
+// @Summary Refresh tokens
+// @Description exchange refresh token for new access and refresh tokens
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @Param body body models.RefreshRequest true "body"
+// @Success 200 {object} models.UserWithToken
+// @Failure 401 {object} httpErrors.RestErr
+// @Failure 500 {object} httpErrors.RestErr
+// @Router /auth/refresh [post]
+func (h *authHandlers) Refresh() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		refresh := &models.RefreshRequest{}
+		if err := utils.ReadRequest(c, refresh); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		userWithToken, err := h.authUC.Refresh(c.Request().Context(), refresh.RefreshToken)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		c.SetCookie(utils.CreateJWTCookie(h.cfg, userWithToken.AccessToken, userWithToken.ExpiresAt))
+
+		return c.JSON(http.StatusOK, userWithToken)
+	}
+}
+
+// GetMe
+// @Summary Get current user
+// @Description get user of current session
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} models.User
+// @Failure 401 {object} httpErrors.RestErr
+// @Router /auth/me [get]
+func (h *authHandlers) GetMe() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		user, err := utils.GetUserFromCtx(c.Request().Context())
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(err))
+		}
+
+		return c.JSON(http.StatusOK, user)
+	}
+}
+
+// GetCSRFToken
+// @Summary Get CSRF token
+// @Description get CSRF token tied to current session, returned in X-CSRF-Token response header
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @Success 204 {string} string	"ok"
+// @Header 204 {string} X-CSRF-Token "CSRF token"
+// @Failure 401 {object} httpErrors.RestErr
+// @Router /auth/token [get]
+func (h *authHandlers) GetCSRFToken() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		sid, ok := c.Get("sid").(string)
+		if !ok {
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		token := csrf.MakeToken(sid, h.cfg.Server.CSRFSalt, time.Second*h.cfg.Server.CSRFExpire, h.logger)
+		c.Response().Header().Set(csrf.CSRFHeader, token)
+		c.Response().Header().Set(echo.HeaderAccessControlExposeHeaders, csrf.CSRFHeader)
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/internal/auth/repository"
+	"github.com/AliIsmoilov/golang_monolight/internal/auth/usecase"
+	"github.com/AliIsmoilov/golang_monolight/pkg/logger"
+)
+
+func TestAuthHandlers_Register(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	level := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	core, _ := observer.New(level)
+	apiLogger := logger.NewCoreLogger(core, level)
+
+	cfg := &config.Config{}
+	authUC := usecase.NewAuthUseCase(cfg, repository.NewAuthRepository(sqlxDB), apiLogger)
+	authHandlers := NewAuthHandlers(cfg, authUC, apiLogger)
+
+	register := func(body string) int {
+		req := httptest.NewRequest(http.MethodPost, "/auth/register", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		require.NoError(t, authHandlers.Register()(echo.New().NewContext(req, rec)))
+		return rec.Code
+	}
+
+	// No query is expected, so sqlmock fails the test when request gets past validation
+	t.Run("Empty password", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, register(`{"first_name":"a","last_name":"b","email":"user@example.com","password":""}`))
+	})
+
+	t.Run("Whitespace password", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, register(`{"first_name":"a","last_name":"b","email":"user@example.com","password":"      "}`))
+	})
+
+	t.Run("Missing email", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, register(`{"first_name":"a","last_name":"b","password":"secret1"}`))
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,18 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/AliIsmoilov/golang_monolight/internal/auth"
+	"github.com/AliIsmoilov/golang_monolight/internal/middleware"
+)
+
+// Map auth routes
+func MapAuthRoutes(authGroup *echo.Group, h auth.Handlers, mw *middleware.MiddlewareManager) {
+	authGroup.POST("/register", h.Register())
+	authGroup.POST("/login", h.Login())
+	authGroup.POST("/refresh", h.Refresh())
+	authGroup.POST("/logout", h.Logout(), mw.AuthJWTMiddleware)
+	authGroup.GET("/me", h.GetMe(), mw.AuthJWTMiddleware)
+	authGroup.GET("/token", h.GetCSRFToken(), mw.AuthJWTMiddleware)
+}
@@ -0,0 +1,21 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository_mock.go -package mock
+package auth
+
+import (
+	"context"
+
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+	"github.com/google/uuid"
+)
+
+// Auth repository interface
+type Repository interface {
+	Register(ctx context.Context, user *models.User) (*models.User, error)
+	GetByID(ctx context.Context, userID uuid.UUID) (*models.User, error)
+	FindByEmail(ctx context.Context, email string) (*models.User, error)
+	CreateSession(ctx context.Context, session *models.Session) (*models.Session, error)
+	GetSessionByRefreshToken(ctx context.Context, refreshToken string) (*models.Session, error)
+	GetUserBySessionID(ctx context.Context, sessionID uuid.UUID) (*models.User, error)
+	RotateSession(ctx context.Context, session *models.Session) (*models.Session, error)
+	DeleteSession(ctx context.Context, sessionID uuid.UUID) error
+}
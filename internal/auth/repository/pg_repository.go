@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/AliIsmoilov/golang_monolight/internal/auth"
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+)
+
+// Auth Repository
+type authRepo struct {
+	db *sqlx.DB
+}
+
+// Auth Repository constructor
+func NewAuthRepository(db *sqlx.DB) auth.Repository {
+	return &authRepo{db: db}
+}
+
+// Create new user
+func (r *authRepo) Register(ctx context.Context, user *models.User) (*models.User, error) {
+	createUser := `
+		INSERT INTO users
+			(id, first_name, last_name, email, password, role)
+		VALUES
+			($1, $2, $3, $4, $5, $6)
+		RETURNING
+			id, first_name, last_name, email, password, role, created_at, updated_at`
+	u := &models.User{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		createUser,
+		uuid.New(),
+		&user.FirstName,
+		&user.LastName,
+		&user.Email,
+		&user.Password,
+		&user.Role,
+	).StructScan(u); err != nil {
+		return nil, errors.Wrap(err, "authRepo.Register.StructScan")
+	}
+
+	return u, nil
+}
+
+// Get user by id
+func (r *authRepo) GetByID(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	getUserByID := `
+		SELECT id, first_name, last_name, email, role, created_at, updated_at
+		FROM users
+		WHERE id = $1`
+	user := &models.User{}
+	if err := r.db.GetContext(ctx, user, getUserByID, userID); err != nil {
+		return nil, errors.Wrap(err, "authRepo.GetByID.GetContext")
+	}
+	return user, nil
+}
+
+// Find user by email
+func (r *authRepo) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	findUserByEmail := `
+		SELECT id, first_name, last_name, email, password, role, created_at, updated_at
+		FROM users
+		WHERE email = $1`
+	user := &models.User{}
+	if err := r.db.GetContext(ctx, user, findUserByEmail, email); err != nil {
+		return nil, errors.Wrap(err, "authRepo.FindByEmail.GetContext")
+	}
+	return user, nil
+}
+
+// Create session
+func (r *authRepo) CreateSession(ctx context.Context, session *models.Session) (*models.Session, error) {
+	createSession := `
+		INSERT INTO sessions
+			(id, user_id, refresh_token, expires_at)
+		VALUES
+			($1, $2, $3, $4)
+		RETURNING
+			id, user_id, refresh_token, expires_at, created_at`
+	s := &models.Session{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		createSession,
+		uuid.New(),
+		&session.UserID,
+		&session.RefreshToken,
+		&session.ExpiresAt,
+	).StructScan(s); err != nil {
+		return nil, errors.Wrap(err, "authRepo.CreateSession.StructScan")
+	}
+
+	return s, nil
+}
+
+// Get not expired session by refresh token hash
+func (r *authRepo) GetSessionByRefreshToken(ctx context.Context, refreshToken string) (*models.Session, error) {
+	getSession := `
+		SELECT id, user_id, refresh_token, expires_at, created_at
+		FROM sessions
+		WHERE refresh_token = $1 AND expires_at > now()`
+	session := &models.Session{}
+	if err := r.db.GetContext(ctx, session, getSession, refreshToken); err != nil {
+		return nil, errors.Wrap(err, "authRepo.GetSessionByRefreshToken.GetContext")
+	}
+	return session, nil
+}
+
+// Get user of not expired session
+func (r *authRepo) GetUserBySessionID(ctx context.Context, sessionID uuid.UUID) (*models.User, error) {
+	getUserBySession := `
+		SELECT u.id, u.first_name, u.last_name, u.email, u.role, u.created_at, u.updated_at
+		FROM sessions s
+		JOIN users u ON u.id = s.user_id
+		WHERE s.id = $1 AND s.expires_at > now()`
+	user := &models.User{}
+	if err := r.db.GetContext(ctx, user, getUserBySession, sessionID); err != nil {
+		return nil, errors.Wrap(err, "authRepo.GetUserBySessionID.GetContext")
+	}
+	return user, nil
+}
+
+// Replace session refresh token and prolong it
+func (r *authRepo) RotateSession(ctx context.Context, session *models.Session) (*models.Session, error) {
+	rotateSession := `
+		UPDATE sessions
+		SET
+			refresh_token = $1,
+			expires_at = $2
+		WHERE id = $3
+		RETURNING id, user_id, refresh_token, expires_at, created_at`
+	s := &models.Session{}
+	if err := r.db.
+		QueryRowxContext(ctx, rotateSession, session.RefreshToken, session.ExpiresAt, session.ID).
+		StructScan(s); err != nil {
+		return nil, errors.Wrap(err, "authRepo.RotateSession.QueryRowxContext")
+	}
+
+	return s, nil
+}
+
+// Delete session
+func (r *authRepo) DeleteSession(ctx context.Context, sessionID uuid.UUID) error {
+	deleteSession := `DELETE FROM sessions WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, deleteSession, sessionID)
+	if err != nil {
+		return errors.Wrap(err, "authRepo.DeleteSession.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "authRepo.DeleteSession.RowsAffected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "authRepo.DeleteSession.rowsAffected")
+	}
+
+	return nil
+}
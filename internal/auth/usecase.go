@@ -0,0 +1,19 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package auth
+
+import (
+	"context"
+
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+	"github.com/google/uuid"
+)
+
+// Auth use case
+type UseCase interface {
+	Register(ctx context.Context, user *models.User) (*models.UserWithToken, error)
+	Login(ctx context.Context, user *models.User) (*models.UserWithToken, error)
+	Logout(ctx context.Context, sessionID uuid.UUID) error
+	Refresh(ctx context.Context, refreshToken string) (*models.UserWithToken, error)
+	GetByID(ctx context.Context, userID uuid.UUID) (*models.User, error)
+	GetBySessionID(ctx context.Context, sessionID uuid.UUID) (*models.User, error)
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/internal/auth"
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+	"github.com/AliIsmoilov/golang_monolight/pkg/httpErrors"
+	"github.com/AliIsmoilov/golang_monolight/pkg/logger"
+	"github.com/AliIsmoilov/golang_monolight/pkg/utils"
+)
+
+// Auth UseCase
+type authUC struct {
+	cfg      *config.Config
+	authRepo auth.Repository
+	logger   logger.Logger
+}
+
+// Auth UseCase constructor
+func NewAuthUseCase(cfg *config.Config, authRepo auth.Repository, logger logger.Logger) auth.UseCase {
+	return &authUC{cfg: cfg, authRepo: authRepo, logger: logger}
+}
+
+// Create new user and log him in
+func (u *authUC) Register(ctx context.Context, user *models.User) (*models.UserWithToken, error) {
+	_, err := u.authRepo.FindByEmail(ctx, user.Email)
+	switch {
+	case err == nil:
+		return nil, httpErrors.NewRestErrorWithMessage(http.StatusBadRequest, httpErrors.ErrEmailAlreadyExists, nil)
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, errors.Wrap(err, "authUC.Register.FindByEmail")
+	}
+
+	// Self registered users are always readers, other roles are granted by admins
+	user.Role = models.RoleReader
+	if err = user.PrepareCreate(); err != nil {
+		return nil, httpErrors.NewBadRequestError(errors.Wrap(err, "authUC.Register.PrepareCreate"))
+	}
+
+	createdUser, err := u.authRepo.Register(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	createdUser.SanitizePassword()
+
+	return u.newSession(ctx, createdUser)
+}
+
+// Login user, returns user with access and refresh tokens
+func (u *authUC) Login(ctx context.Context, user *models.User) (*models.UserWithToken, error) {
+	foundUser, err := u.authRepo.FindByEmail(ctx, user.Email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, httpErrors.NewUnauthorizedError(httpErrors.WrongCredentials)
+		}
+		return nil, err
+	}
+
+	if err = foundUser.ComparePasswords(user.Password); err != nil {
+		return nil, httpErrors.NewUnauthorizedError(httpErrors.WrongCredentials)
+	}
+	foundUser.SanitizePassword()
+
+	return u.newSession(ctx, foundUser)
+}
+
+// Logout deletes user session, its access and refresh tokens stop working
+func (u *authUC) Logout(ctx context.Context, sessionID uuid.UUID) error {
+	return u.authRepo.DeleteSession(ctx, sessionID)
+}
+
+// Refresh rotates session refresh token and issues new access token
+func (u *authUC) Refresh(ctx context.Context, refreshToken string) (*models.UserWithToken, error) {
+	session, err := u.authRepo.GetSessionByRefreshToken(ctx, utils.HashRefreshToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, httpErrors.NewUnauthorizedError(httpErrors.InvalidJWTToken)
+		}
+		return nil, err
+	}
+
+	user, err := u.authRepo.GetByID(ctx, session.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	newRefreshToken, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.Refresh.GenerateRefreshToken"))
+	}
+
+	session.RefreshToken = utils.HashRefreshToken(newRefreshToken)
+	session.ExpiresAt = time.Now().Add(time.Second * u.cfg.Server.RefreshTokenExpire)
+	session, err = u.authRepo.RotateSession(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.withTokens(user, session.ID, newRefreshToken)
+}
+
+// Get user by id
+func (u *authUC) GetByID(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	return u.authRepo.GetByID(ctx, userID)
+}
+
+// Get user of active session
+func (u *authUC) GetBySessionID(ctx context.Context, sessionID uuid.UUID) (*models.User, error) {
+	return u.authRepo.GetUserBySessionID(ctx, sessionID)
+}
+
+func (u *authUC) newSession(ctx context.Context, user *models.User) (*models.UserWithToken, error) {
+	refreshToken, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.newSession.GenerateRefreshToken"))
+	}
+
+	session, err := u.authRepo.CreateSession(ctx, &models.Session{
+		UserID:       user.ID,
+		RefreshToken: utils.HashRefreshToken(refreshToken),
+		ExpiresAt:    time.Now().Add(time.Second * u.cfg.Server.RefreshTokenExpire),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.withTokens(user, session.ID, refreshToken)
+}
+
+func (u *authUC) withTokens(user *models.User, sessionID uuid.UUID, refreshToken string) (*models.UserWithToken, error) {
+	accessToken, expiresAt, err := utils.GenerateJWTToken(user, sessionID, u.cfg)
+	if err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.withTokens.GenerateJWTToken"))
+	}
+
+	return &models.UserWithToken{
+		User:         user,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
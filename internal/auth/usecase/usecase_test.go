@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/internal/auth/repository"
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+	"github.com/AliIsmoilov/golang_monolight/pkg/httpErrors"
+)
+
+func TestAuthUC_Register(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	authUC := NewAuthUseCase(&config.Config{}, repository.NewAuthRepository(sqlxDB), nil)
+	findByEmail := `SELECT id, first_name, last_name, email, password, role, created_at, updated_at\s+FROM users\s+WHERE email = \$1`
+
+	t.Run("Email exists", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "first_name", "last_name", "email", "password", "role", "created_at", "updated_at"}).
+			AddRow(uuid.New(), "first", "last", "user@example.com", "hash", models.RoleReader, time.Now(), time.Now())
+		mock.ExpectQuery(findByEmail).WithArgs("user@example.com").WillReturnRows(rows)
+
+		_, err := authUC.Register(context.Background(), &models.User{Email: "user@example.com"})
+		status, _ := httpErrors.ErrorResponse(err)
+		require.Equal(t, http.StatusBadRequest, status)
+		require.ErrorContains(t, err, httpErrors.ErrEmailAlreadyExists)
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		dbErr := errors.New("connection reset")
+		mock.ExpectQuery(findByEmail).WithArgs("user@example.com").WillReturnError(dbErr)
+
+		// No insert is expected, so sqlmock fails the test when registration goes on
+		_, err := authUC.Register(context.Background(), &models.User{Email: "user@example.com"})
+		require.ErrorIs(t, err, dbErr)
+	})
+
+	t.Run("Whitespace password", func(t *testing.T) {
+		mock.ExpectQuery(findByEmail).WithArgs("new@example.com").WillReturnError(sql.ErrNoRows)
+
+		// No insert is expected, password is empty after trimming
+		_, err := authUC.Register(context.Background(), &models.User{Email: "new@example.com", Password: "      "})
+		status, _ := httpErrors.ErrorResponse(err)
+		require.Equal(t, http.StatusBadRequest, status)
+		require.ErrorContains(t, err, models.ErrPasswordTooShort.Error())
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
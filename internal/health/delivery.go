@@ -0,0 +1,9 @@
+package health
+
+import "github.com/labstack/echo/v4"
+
+// Health HTTP Handlers interface
+type Handlers interface {
+	Live() echo.HandlerFunc
+	Ready() echo.HandlerFunc
+}
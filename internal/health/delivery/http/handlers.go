@@ -0,0 +1,57 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/internal/health"
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+	"github.com/AliIsmoilov/golang_monolight/pkg/logger"
+)
+
+// Health handlers
+type healthHandlers struct {
+	cfg      *config.Config
+	healthUC health.UseCase
+	logger   logger.Logger
+}
+
+// Health handlers constructor
+func NewHealthHandlers(cfg *config.Config, healthUC health.UseCase, logger logger.Logger) health.Handlers {
+	return &healthHandlers{cfg: cfg, healthUC: healthUC, logger: logger}
+}
+
+// Live
+// @Summary Liveness probe
+// @Description process is up, dependencies are not checked
+// @Tags Health
+// @Produce  json
+// @Success 200 {object} map[string]string
+// @Router /health/live [get]
+func (h *healthHandlers) Live() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]string{"status": models.HealthAlive})
+	}
+}
+
+// Ready
+// @Summary Readiness probe
+// @Description checks postgres, schema version and shutdown state, returns 503 when not ready
+// @Tags Health
+// @Produce  json
+// @Success 200 {object} models.Readiness
+// @Failure 503 {object} models.Readiness
+// @Router /health/ready [get]
+func (h *healthHandlers) Ready() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		readiness := h.healthUC.Ready(c.Request().Context())
+		if readiness.Status != models.HealthReady {
+			return c.JSON(http.StatusServiceUnavailable, readiness)
+		}
+
+		return c.JSON(http.StatusOK, readiness)
+	}
+}
@@ -0,0 +1,14 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/AliIsmoilov/golang_monolight/internal/health"
+)
+
+// Map health routes, root path is kept for old probes and reports readiness
+func MapHealthRoutes(healthGroup *echo.Group, h health.Handlers) {
+	healthGroup.GET("", h.Ready())
+	healthGroup.GET("/live", h.Live())
+	healthGroup.GET("/ready", h.Ready())
+}
@@ -0,0 +1,13 @@
+package health
+
+import (
+	"context"
+
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+)
+
+// Health use case
+type UseCase interface {
+	Ready(ctx context.Context) *models.Readiness
+	MarkShuttingDown()
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/internal/health"
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+	"github.com/AliIsmoilov/golang_monolight/pkg/db/migrate"
+	"github.com/AliIsmoilov/golang_monolight/pkg/logger"
+)
+
+// Health UseCase
+type healthUC struct {
+	cfg          *config.Config
+	db           *sqlx.DB
+	migrator     *migrate.Migrator
+	shuttingDown atomic.Bool
+	logger       logger.Logger
+}
+
+// Health UseCase constructor
+func NewHealthUseCase(cfg *config.Config, db *sqlx.DB, migrator *migrate.Migrator, logger logger.Logger) health.UseCase {
+	return &healthUC{cfg: cfg, db: db, migrator: migrator, logger: logger}
+}
+
+// Ready checks dependencies, instance is not ready while shutting down so load balancer stops routing to it
+func (u *healthUC) Ready(ctx context.Context) *models.Readiness {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*u.cfg.Server.ReadinessTimeout)
+	defer cancel()
+
+	checks := map[string]*models.HealthCheck{
+		"postgres":   u.checkPostgres(ctx),
+		"migrations": u.checkMigrations(ctx),
+	}
+	if u.shuttingDown.Load() {
+		checks["shutdown"] = &models.HealthCheck{Status: models.HealthDown, Error: "server is shutting down"}
+	}
+
+	status := models.HealthReady
+	for _, check := range checks {
+		if check.Status != models.HealthUp {
+			status = models.HealthNotReady
+		}
+	}
+
+	stats := u.db.Stats()
+	return &models.Readiness{
+		Status: status,
+		Checks: checks,
+		Pool: &models.PoolStats{
+			MaxOpen:      stats.MaxOpenConnections,
+			Open:         stats.OpenConnections,
+			InUse:        stats.InUse,
+			Idle:         stats.Idle,
+			WaitCount:    stats.WaitCount,
+			WaitDuration: stats.WaitDuration.Milliseconds(),
+		},
+	}
+}
+
+// Mark instance as shutting down, readiness fails from now on
+func (u *healthUC) MarkShuttingDown() {
+	u.shuttingDown.Store(true)
+}
+
+func (u *healthUC) checkPostgres(ctx context.Context) *models.HealthCheck {
+	start := time.Now()
+	if err := u.db.PingContext(ctx); err != nil {
+		u.logger.FromContext(ctx).Warnf("healthUC.checkPostgres.PingContext: %v", err)
+		return &models.HealthCheck{Status: models.HealthDown, Error: "ping failed"}
+	}
+	return &models.HealthCheck{Status: models.HealthUp, LatencyMs: time.Since(start).Milliseconds()}
+}
+
+// Schema must be at latest embedded migration and clean
+func (u *healthUC) checkMigrations(ctx context.Context) *models.HealthCheck {
+	expected := u.migrator.Latest()
+	version, dirty, err := u.migrator.Version(ctx)
+	if err != nil {
+		u.logger.FromContext(ctx).Warnf("healthUC.checkMigrations.Version: %v", err)
+		return &models.HealthCheck{Status: models.HealthDown, Error: "schema version is unknown", Expected: &expected}
+	}
+
+	check := &models.HealthCheck{Status: models.HealthUp, Version: &version, Expected: &expected, Dirty: dirty}
+	switch {
+	case dirty:
+		check.Status, check.Error = models.HealthDown, "schema is dirty"
+	case version < expected:
+		check.Status, check.Error = models.HealthDown, "migrations are pending"
+	}
+	return check
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+	"github.com/AliIsmoilov/golang_monolight/pkg/db/migrate"
+	"github.com/AliIsmoilov/golang_monolight/pkg/logger"
+)
+
+var testFS = fstest.MapFS{
+	"01_users.up.sql":   {Data: []byte("CREATE TABLE users (id INT);")},
+	"01_users.down.sql": {Data: []byte("DROP TABLE users;")},
+	"02_news.up.sql":    {Data: []byte("CREATE TABLE news (id INT);")},
+	"02_news.down.sql":  {Data: []byte("DROP TABLE news;")},
+}
+
+func TestHealthUC_Ready(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	migrator, err := migrate.NewMigrator(sqlxDB, testFS)
+	require.NoError(t, err)
+
+	cfg := &config.Config{Server: config.ServerConfig{ReadinessTimeout: 1}, Logger: config.Logger{Level: "fatal"}}
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	healthUC := NewHealthUseCase(cfg, sqlxDB, migrator, apiLogger)
+
+	t.Run("Ready", func(t *testing.T) {
+		mock.ExpectPing()
+		mock.ExpectQuery("SELECT version, dirty FROM schema_migrations").
+			WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(2, false))
+
+		readiness := healthUC.Ready(context.Background())
+		require.Equal(t, models.HealthReady, readiness.Status)
+		require.Equal(t, models.HealthUp, readiness.Checks["postgres"].Status)
+		require.Equal(t, 2, *readiness.Checks["migrations"].Version)
+		require.NotNil(t, readiness.Pool)
+	})
+
+	t.Run("PendingMigrations", func(t *testing.T) {
+		mock.ExpectPing()
+		mock.ExpectQuery("SELECT version, dirty FROM schema_migrations").
+			WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(1, false))
+
+		readiness := healthUC.Ready(context.Background())
+		require.Equal(t, models.HealthNotReady, readiness.Status)
+		require.Equal(t, models.HealthDown, readiness.Checks["migrations"].Status)
+		require.Equal(t, 2, *readiness.Checks["migrations"].Expected)
+	})
+
+	t.Run("ShuttingDown", func(t *testing.T) {
+		mock.ExpectPing()
+		mock.ExpectQuery("SELECT version, dirty FROM schema_migrations").
+			WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(2, false))
+
+		healthUC.MarkShuttingDown()
+		readiness := healthUC.Ready(context.Background())
+		require.Equal(t, models.HealthNotReady, readiness.Status)
+		require.Equal(t, models.HealthDown, readiness.Checks["shutdown"].Status)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
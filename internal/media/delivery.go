@@ -0,0 +1,9 @@
+package media
+
+import "github.com/labstack/echo/v4"
+
+// Media HTTP Handlers interface
+type Handlers interface {
+	Upload() echo.HandlerFunc
+	GetByID() echo.HandlerFunc
+}
@@ -0,0 +1,149 @@
+package http
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/internal/media"
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+	"github.com/AliIsmoilov/golang_monolight/pkg/httpErrors"
+	"github.com/AliIsmoilov/golang_monolight/pkg/logger"
+	"github.com/AliIsmoilov/golang_monolight/pkg/utils"
+)
+
+const (
+	formFileField   = "file"
+	sniffLen        = 512
+	maxFileNameSize = 255
+)
+
+// Media handlers
+type mediaHandlers struct {
+	cfg     *config.Config
+	mediaUC media.UseCase
+	logger  logger.Logger
+}
+
+// NewMediaHandlers Media handlers constructor
+func NewMediaHandlers(cfg *config.Config, mediaUC media.UseCase, logger logger.Logger) media.Handlers {
+	return &mediaHandlers{cfg: cfg, mediaUC: mediaUC, logger: logger}
+}
+
+// Upload
+// @Summary Upload media
+// @Description upload image, content type is detected from file content, EXIF and other metadata are stripped
+// @Tags Media
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param file formData file true "image file"
+// @Success 201 {object} models.Media
+// @Failure 400 {object} httpErrors.RestErr
+// @Failure 401 {object} httpErrors.RestErr
+// @Failure 403 {object} httpErrors.RestErr
+// @Failure 413 {object} httpErrors.RestErr
+// @Failure 500 {object} httpErrors.RestErr
+// @Router /media [post]
+func (h *mediaHandlers) Upload() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		fileHeader, err := c.FormFile(formFileField)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewBadRequestError(errors.Wrap(err, "FormFile")))
+		}
+
+		if fileHeader.Size > h.cfg.Media.MaxUploadSize {
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewRestError(http.StatusRequestEntityTooLarge, httpErrors.ErrFileTooLarge, nil))
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewBadRequestError(errors.Wrap(err, "FileHeader.Open")))
+		}
+		defer file.Close()
+
+		// Content type is sniffed, client provided Content-Type header is not trusted
+		head := make([]byte, sniffLen)
+		n, err := io.ReadFull(file, head)
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewBadRequestError(errors.Wrap(err, "ReadFull")))
+		}
+		head = head[:n]
+
+		if _, err = utils.CheckImageFileContentType(head); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewBadRequestError(httpErrors.NotAllowedImageHeader))
+		}
+
+		createdMedia, err := h.mediaUC.Upload(c.Request().Context(), &models.UploadInput{
+			File:        io.MultiReader(bytes.NewReader(head), file),
+			FileName:    cleanFileName(fileHeader.Filename),
+			ContentType: http.DetectContentType(head),
+			Size:        fileHeader.Size,
+		})
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusCreated, createdMedia)
+	}
+}
+
+// GetByID
+// @Summary Get media
+// @Description Get media file content by id, variant selects resized image, original is served until variant is generated
+// @Tags Media
+// @Produce  octet-stream
+// @Param id path string true "id"
+// @Param variant query string false "thumb, card or full"
+// @Success 200 {file} file
+// @Failure 400 {object} httpErrors.RestErr
+// @Failure 404 {object} httpErrors.RestErr
+// @Failure 500 {object} httpErrors.RestErr
+// @Router /media/{id} [get]
+func (h *mediaHandlers) GetByID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		mediaID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		mf, err := h.mediaUC.Download(c.Request().Context(), mediaID, c.QueryParam("variant"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+		defer mf.File.Close()
+
+		// Media is immutable, new upload always gets new id.
+		// Original served in place of not yet generated variant must be revalidated.
+		header := c.Response().Header()
+		header.Set(echo.HeaderContentLength, strconv.FormatInt(mf.Size, 10))
+		if mf.Immutable {
+			header.Set("Cache-Control", "public, max-age=31536000, immutable")
+		} else {
+			header.Set("Cache-Control", "no-cache")
+		}
+		header.Set("X-Content-Type-Options", "nosniff")
+
+		return c.Stream(http.StatusOK, mf.ContentType, mf.File)
+	}
+}
+
+func cleanFileName(name string) string {
+	name = filepath.Base(filepath.Clean("/" + name))
+	if name == "/" || name == "." {
+		return "file"
+	}
+	if len(name) > maxFileNameSize {
+		name = name[len(name)-maxFileNameSize:]
+	}
+	return name
+}
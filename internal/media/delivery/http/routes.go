@@ -0,0 +1,23 @@
+package http
+
+import (
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/internal/media"
+	"github.com/AliIsmoilov/golang_monolight/internal/middleware"
+)
+
+// Multipart headers and boundaries on top of the file itself
+const multipartOverhead = 64 << 10
+
+// Map media routes
+func MapMediaRoutes(mediaGroup *echo.Group, h media.Handlers, mw *middleware.MiddlewareManager, cfg *config.Config) {
+	bodyLimit := echoMiddleware.BodyLimit(strconv.FormatInt(cfg.Media.MaxUploadSize+multipartOverhead, 10))
+
+	mediaGroup.POST("", h.Upload(), bodyLimit, mw.AuthJWTMiddleware, mw.CSRF)
+	mediaGroup.GET("/:id", h.GetByID())
+}
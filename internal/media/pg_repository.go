@@ -0,0 +1,22 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository_mock.go -package mock
+package media
+
+import (
+	"context"
+	"time"
+
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+	"github.com/google/uuid"
+)
+
+// Media repository interface
+type Repository interface {
+	Create(ctx context.Context, media *models.Media) (*models.Media, error)
+	GetByID(ctx context.Context, mediaID uuid.UUID) (*models.Media, error)
+	Delete(ctx context.Context, mediaID uuid.UUID) error
+	UpdateStatus(ctx context.Context, mediaID uuid.UUID, status string) error
+	Claim(ctx context.Context, mediaID uuid.UUID, staleAfter time.Duration) (*models.Media, error)
+	GetPendingIDs(ctx context.Context, staleAfter time.Duration, limit int) ([]uuid.UUID, error)
+	SaveVariant(ctx context.Context, variant *models.MediaVariant) (*models.MediaVariant, error)
+	GetVariant(ctx context.Context, mediaID uuid.UUID, variant string) (*models.MediaVariant, error)
+}
@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/AliIsmoilov/golang_monolight/internal/media"
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+)
+
+// Media Repository
+type mediaRepo struct {
+	db *sqlx.DB
+}
+
+// Media Repository constructor
+func NewMediaRepository(db *sqlx.DB) media.Repository {
+	return &mediaRepo{db: db}
+}
+
+// Create media record
+func (r *mediaRepo) Create(ctx context.Context, m *models.Media) (*models.Media, error) {
+	createMedia := `
+		INSERT INTO media
+			(id, owner_id, file_name, content_type, size, storage_key)
+		VALUES
+			($1, $2, $3, $4, $5, $6)
+		RETURNING
+			id, owner_id, file_name, content_type, size, storage_key, status, created_at`
+	res := &models.Media{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		createMedia,
+		m.ID,
+		m.OwnerID,
+		m.FileName,
+		m.ContentType,
+		m.Size,
+		m.StorageKey,
+	).StructScan(res); err != nil {
+		return nil, errors.Wrap(err, "mediaRepo.Create.StructScan")
+	}
+
+	return res, nil
+}
+
+// Get media by id
+func (r *mediaRepo) GetByID(ctx context.Context, mediaID uuid.UUID) (*models.Media, error) {
+	getMediaByID := `
+		SELECT id, owner_id, file_name, content_type, size, storage_key, status, created_at
+		FROM media
+		WHERE id = $1`
+	m := &models.Media{}
+	if err := r.db.GetContext(ctx, m, getMediaByID, mediaID); err != nil {
+		return nil, errors.Wrap(err, "mediaRepo.GetByID.GetContext")
+	}
+	return m, nil
+}
+
+// Delete media record
+func (r *mediaRepo) Delete(ctx context.Context, mediaID uuid.UUID) error {
+	deleteMedia := `DELETE FROM media WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, deleteMedia, mediaID)
+	if err != nil {
+		return errors.Wrap(err, "mediaRepo.Delete.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "mediaRepo.Delete.RowsAffected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "mediaRepo.Delete.rowsAffected")
+	}
+
+	return nil
+}
+
+// Set media variants processing status
+func (r *mediaRepo) UpdateStatus(ctx context.Context, mediaID uuid.UUID, status string) error {
+	updateStatus := `UPDATE media SET status = $1 WHERE id = $2`
+
+	result, err := r.db.ExecContext(ctx, updateStatus, status, mediaID)
+	if err != nil {
+		return errors.Wrap(err, "mediaRepo.UpdateStatus.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "mediaRepo.UpdateStatus.RowsAffected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "mediaRepo.UpdateStatus.rowsAffected")
+	}
+
+	return nil
+}
+
+// Claim media for variants processing, only one worker gets pending media or media whose claim is stale.
+// Returns sql.ErrNoRows when media is already claimed or processed.
+func (r *mediaRepo) Claim(ctx context.Context, mediaID uuid.UUID, staleAfter time.Duration) (*models.Media, error) {
+	claimMedia := `
+		UPDATE media
+		SET status = 'processing', claimed_at = now()
+		WHERE id = $1 AND (
+			status = 'pending' OR
+			(status = 'processing' AND claimed_at < now() - $2 * INTERVAL '1 second')
+		)
+		RETURNING
+			id, owner_id, file_name, content_type, size, storage_key, status, created_at`
+	m := &models.Media{}
+	if err := r.db.GetContext(ctx, m, claimMedia, mediaID, staleAfter.Seconds()); err != nil {
+		return nil, errors.Wrap(err, "mediaRepo.Claim.GetContext")
+	}
+	return m, nil
+}
+
+// Get ids of media left behind by queue, pending or claimed longer than staleAfter ago, oldest first
+func (r *mediaRepo) GetPendingIDs(ctx context.Context, staleAfter time.Duration, limit int) ([]uuid.UUID, error) {
+	getPendingIDs := `
+		SELECT id
+		FROM media
+		WHERE (status = 'pending' AND created_at < now() - $1 * INTERVAL '1 second') OR
+			(status = 'processing' AND claimed_at < now() - $1 * INTERVAL '1 second')
+		ORDER BY created_at
+		LIMIT $2`
+	ids := make([]uuid.UUID, 0, limit)
+	if err := r.db.SelectContext(ctx, &ids, getPendingIDs, staleAfter.Seconds(), limit); err != nil {
+		return nil, errors.Wrap(err, "mediaRepo.GetPendingIDs.SelectContext")
+	}
+	return ids, nil
+}
+
+// Create or replace media variant
+func (r *mediaRepo) SaveVariant(ctx context.Context, v *models.MediaVariant) (*models.MediaVariant, error) {
+	saveVariant := `
+		INSERT INTO media_variants
+			(media_id, variant, content_type, size, width, height, storage_key)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (media_id, variant) DO UPDATE SET
+			content_type = EXCLUDED.content_type,
+			size = EXCLUDED.size,
+			width = EXCLUDED.width,
+			height = EXCLUDED.height,
+			storage_key = EXCLUDED.storage_key
+		RETURNING
+			media_id, variant, content_type, size, width, height, storage_key, created_at`
+	res := &models.MediaVariant{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		saveVariant,
+		v.MediaID,
+		v.Variant,
+		v.ContentType,
+		v.Size,
+		v.Width,
+		v.Height,
+		v.StorageKey,
+	).StructScan(res); err != nil {
+		return nil, errors.Wrap(err, "mediaRepo.SaveVariant.StructScan")
+	}
+
+	return res, nil
+}
+
+// Get media variant
+func (r *mediaRepo) GetVariant(ctx context.Context, mediaID uuid.UUID, variant string) (*models.MediaVariant, error) {
+	getVariant := `
+		SELECT media_id, variant, content_type, size, width, height, storage_key, created_at
+		FROM media_variants
+		WHERE media_id = $1 AND variant = $2`
+	v := &models.MediaVariant{}
+	if err := r.db.GetContext(ctx, v, getVariant, mediaID, variant); err != nil {
+		return nil, errors.Wrap(err, "mediaRepo.GetVariant.GetContext")
+	}
+	return v, nil
+}
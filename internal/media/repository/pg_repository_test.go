@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+)
+
+func TestMediaRepo_Claim(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	mediaRepo := NewMediaRepository(sqlxDB)
+	claimQuery := `UPDATE media\s+SET status = 'processing', claimed_at = now\(\)\s+WHERE id = \$1 AND \(\s+status = 'pending' OR\s+` +
+		`\(status = 'processing' AND claimed_at < now\(\) - \$2 \* INTERVAL '1 second'\)\s+\)`
+
+	t.Run("Claim", func(t *testing.T) {
+		mediaID := uuid.New()
+		rows := sqlmock.NewRows([]string{"id", "owner_id", "file_name", "content_type", "size", "storage_key", "status", "created_at"}).
+			AddRow(mediaID, uuid.New(), "photo.jpg", "image/jpeg", 100, mediaID.String(), models.MediaStatusProcessing, time.Now())
+		mock.ExpectQuery(claimQuery).WithArgs(mediaID, float64(300)).WillReturnRows(rows)
+
+		m, err := mediaRepo.Claim(context.Background(), mediaID, 300*time.Second)
+		require.NoError(t, err)
+		require.Equal(t, models.MediaStatusProcessing, m.Status)
+	})
+
+	t.Run("Already claimed", func(t *testing.T) {
+		mediaID := uuid.New()
+		mock.ExpectQuery(claimQuery).WithArgs(mediaID, float64(300)).WillReturnError(sql.ErrNoRows)
+
+		_, err := mediaRepo.Claim(context.Background(), mediaID, 300*time.Second)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,14 @@
+//go:generate mockgen -source storage.go -destination mock/storage_mock.go -package mock
+package media
+
+import (
+	"context"
+	"io"
+)
+
+// Media file storage interface
+type Storage interface {
+	Put(ctx context.Context, key string, file io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
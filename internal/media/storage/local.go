@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/AliIsmoilov/golang_monolight/internal/media"
+)
+
+// Local filesystem storage, keys are file names inside dir
+type localStorage struct {
+	dir string
+}
+
+// Local filesystem storage constructor, creates dir if it does not exist
+func NewLocalStorage(dir string) (media.Storage, error) {
+	if dir == "" {
+		return nil, errors.New("localStorage: empty dir")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Wrap(err, "localStorage.MkdirAll")
+	}
+	return &localStorage{dir: dir}, nil
+}
+
+// Put writes file to temp file first, so readers never see partially written file
+func (s *localStorage) Put(ctx context.Context, key string, file io.Reader, size int64, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return errors.Wrap(err, "localStorage.Put.CreateTemp")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = io.Copy(tmp, file); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "localStorage.Put.Copy")
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.Wrap(err, "localStorage.Put.Close")
+	}
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return errors.Wrap(err, "localStorage.Put.Rename")
+	}
+
+	return nil
+}
+
+// Get opens file, missing file error matches os.ErrNotExist
+func (s *localStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "localStorage.Get.Open")
+	}
+	return file, nil
+}
+
+// Delete removes file, missing file is not an error
+func (s *localStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "localStorage.Delete.Remove")
+	}
+	return nil
+}
+
+// Keys must be plain file names, so they can not point outside of dir
+func (s *localStorage) path(key string) (string, error) {
+	if key == "" || key == "." || key == ".." || filepath.Base(key) != key {
+		return "", errors.Errorf("localStorage: invalid key %q", key)
+	}
+	return filepath.Join(s.dir, key), nil
+}
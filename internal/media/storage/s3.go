@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/pkg/errors"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/internal/media"
+)
+
+const noSuchKey = "NoSuchKey"
+
+// S3 compatible storage, works with AWS S3 and MinIO
+type s3Storage struct {
+	client *minio.Client
+	bucket string
+}
+
+// S3 storage constructor, creates bucket if it does not exist
+func NewS3Storage(ctx context.Context, cfg *config.Config) (media.Storage, error) {
+	client, err := minio.New(cfg.Media.S3Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.Media.S3AccessKey, cfg.Media.S3SecretKey, ""),
+		Secure: cfg.Media.S3UseSSL,
+		Region: cfg.Media.S3Region,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s3Storage.New")
+	}
+
+	exists, err := client.BucketExists(ctx, cfg.Media.S3Bucket)
+	if err != nil {
+		return nil, errors.Wrap(err, "s3Storage.BucketExists")
+	}
+	if !exists {
+		if err = client.MakeBucket(ctx, cfg.Media.S3Bucket, minio.MakeBucketOptions{Region: cfg.Media.S3Region}); err != nil {
+			return nil, errors.Wrap(err, "s3Storage.MakeBucket")
+		}
+	}
+
+	return &s3Storage{client: client, bucket: cfg.Media.S3Bucket}, nil
+}
+
+// Put uploads object
+func (s *s3Storage) Put(ctx context.Context, key string, file io.Reader, size int64, contentType string) error {
+	if _, err := s.client.PutObject(ctx, s.bucket, key, file, size, minio.PutObjectOptions{ContentType: contentType}); err != nil {
+		return errors.Wrap(err, "s3Storage.Put.PutObject")
+	}
+	return nil
+}
+
+// Get downloads object, missing object error matches os.ErrNotExist
+func (s *s3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	object, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "s3Storage.Get.GetObject")
+	}
+
+	// GetObject is lazy, Stat makes request and reports missing object
+	if _, err = object.Stat(); err != nil {
+		object.Close()
+		if minio.ToErrorResponse(err).Code == noSuchKey {
+			return nil, errors.Wrap(os.ErrNotExist, "s3Storage.Get.Stat")
+		}
+		return nil, errors.Wrap(err, "s3Storage.Get.Stat")
+	}
+
+	return object, nil
+}
+
+// Delete removes object, missing object is not an error
+func (s *s3Storage) Delete(ctx context.Context, key string) error {
+	if err := s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return errors.Wrap(err, "s3Storage.Delete.RemoveObject")
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/internal/media"
+)
+
+// Storage types
+const (
+	Local = "local"
+	S3    = "s3"
+)
+
+// Create media storage configured by cfg.Media.Storage, local storage is default
+func NewStorage(ctx context.Context, cfg *config.Config) (media.Storage, error) {
+	switch cfg.Media.Storage {
+	case "", Local:
+		return NewLocalStorage(cfg.Media.LocalDir)
+	case S3:
+		return NewS3Storage(ctx, cfg)
+	default:
+		return nil, fmt.Errorf("unknown media storage: %s", cfg.Media.Storage)
+	}
+}
@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/internal/media"
+)
+
+func TestLocalStorage(t *testing.T) {
+	t.Parallel()
+
+	s, err := NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	testStorage(t, s)
+
+	t.Run("Invalid key", func(t *testing.T) {
+		err := s.Put(context.Background(), "../file", bytes.NewReader([]byte("data")), 4, "text/plain")
+		require.Error(t, err)
+	})
+}
+
+// Runs against MinIO or other S3 compatible storage, e.g.
+// S3_TEST_ENDPOINT=localhost:9000 S3_TEST_ACCESS_KEY=minioadmin S3_TEST_SECRET_KEY=minioadmin go test ./internal/media/storage
+func TestS3Storage(t *testing.T) {
+	endpoint := os.Getenv("S3_TEST_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("S3_TEST_ENDPOINT is not set")
+	}
+
+	s, err := NewS3Storage(context.Background(), &config.Config{Media: config.MediaConfig{
+		S3Endpoint:  endpoint,
+		S3AccessKey: os.Getenv("S3_TEST_ACCESS_KEY"),
+		S3SecretKey: os.Getenv("S3_TEST_SECRET_KEY"),
+		S3Bucket:    "media-test",
+		S3Region:    "us-east-1",
+	}})
+	require.NoError(t, err)
+
+	testStorage(t, s)
+}
+
+func testStorage(t *testing.T, s media.Storage) {
+	ctx := context.Background()
+	key := uuid.New().String()
+	content := []byte("file content")
+
+	t.Run("Put and Get", func(t *testing.T) {
+		require.NoError(t, s.Put(ctx, key, bytes.NewReader(content), int64(len(content)), "text/plain"))
+
+		file, err := s.Get(ctx, key)
+		require.NoError(t, err)
+		defer file.Close()
+
+		got, err := io.ReadAll(file)
+		require.NoError(t, err)
+		require.Equal(t, content, got)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, s.Delete(ctx, key))
+		require.NoError(t, s.Delete(ctx, key))
+
+		_, err := s.Get(ctx, key)
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
@@ -0,0 +1,23 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package media
+
+import (
+	"context"
+
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+	"github.com/google/uuid"
+)
+
+// Media use case
+type UseCase interface {
+	Upload(ctx context.Context, input *models.UploadInput) (*models.Media, error)
+	GetByID(ctx context.Context, mediaID uuid.UUID) (*models.Media, error)
+	Download(ctx context.Context, mediaID uuid.UUID, variant string) (*models.MediaFile, error)
+	ProcessVariants(ctx context.Context, mediaID uuid.UUID) error
+	GetPendingIDs(ctx context.Context, limit int) ([]uuid.UUID, error)
+}
+
+// Queue of media waiting for variants processing
+type Queue interface {
+	Enqueue(mediaID uuid.UUID) bool
+}
@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/internal/media"
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+	"github.com/AliIsmoilov/golang_monolight/pkg/httpErrors"
+	"github.com/AliIsmoilov/golang_monolight/pkg/imaging"
+	"github.com/AliIsmoilov/golang_monolight/pkg/logger"
+	"github.com/AliIsmoilov/golang_monolight/pkg/utils"
+)
+
+// Media UseCase
+type mediaUC struct {
+	cfg       *config.Config
+	mediaRepo media.Repository
+	storage   media.Storage
+	queue     media.Queue
+	logger    logger.Logger
+}
+
+// Media UseCase constructor
+func NewMediaUseCase(cfg *config.Config, mediaRepo media.Repository, storage media.Storage, queue media.Queue, logger logger.Logger) media.UseCase {
+	return &mediaUC{cfg: cfg, mediaRepo: mediaRepo, storage: storage, queue: queue, logger: logger}
+}
+
+// Upload stores file without metadata and creates media record, only publishers may upload.
+// Variants are generated asynchronously, until then original file is served.
+func (u *mediaUC) Upload(ctx context.Context, input *models.UploadInput) (*models.Media, error) {
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(err)
+	}
+	if !user.CanPublish() {
+		return nil, httpErrors.NewForbiddenError(httpErrors.PermissionDenied)
+	}
+
+	if input.Size > u.cfg.Media.MaxUploadSize {
+		return nil, httpErrors.NewRestError(http.StatusRequestEntityTooLarge, httpErrors.ErrFileTooLarge, nil)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(input.File, u.cfg.Media.MaxUploadSize+1))
+	if err != nil {
+		return nil, httpErrors.NewBadRequestError(errors.Wrap(err, "mediaUC.Upload.ReadAll"))
+	}
+	if int64(len(data)) > u.cfg.Media.MaxUploadSize {
+		return nil, httpErrors.NewRestError(http.StatusRequestEntityTooLarge, httpErrors.ErrFileTooLarge, nil)
+	}
+
+	// Original is public as well, so EXIF with GPS and camera data is stripped before it is stored
+	data, contentType, err := imaging.StripMetadata(data, input.ContentType)
+	if err != nil {
+		return nil, httpErrors.NewBadRequestError(errors.Wrap(err, "mediaUC.Upload.StripMetadata"))
+	}
+
+	mediaID := uuid.New()
+	m := &models.Media{
+		ID:          mediaID,
+		OwnerID:     user.ID,
+		FileName:    input.FileName,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		StorageKey:  mediaID.String(),
+	}
+
+	if err = u.storage.Put(ctx, m.StorageKey, bytes.NewReader(data), m.Size, m.ContentType); err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "mediaUC.Upload.storage.Put"))
+	}
+
+	createdMedia, err := u.mediaRepo.Create(ctx, m)
+	if err != nil {
+		if delErr := u.storage.Delete(ctx, m.StorageKey); delErr != nil {
+			u.logger.FromContext(ctx).Errorf("mediaUC.Upload.storage.Delete: %v", delErr)
+		}
+		return nil, err
+	}
+
+	// Full queue is fine, pending media are picked up by periodic sweep
+	if !u.queue.Enqueue(createdMedia.ID) {
+		u.logger.FromContext(ctx).With("media_id", createdMedia.ID).Warn("mediaUC.Upload: variants queue is full, media waits for sweep")
+	}
+
+	return createdMedia, nil
+}
+
+// Get media by id
+func (u *mediaUC) GetByID(ctx context.Context, mediaID uuid.UUID) (*models.Media, error) {
+	return u.mediaRepo.GetByID(ctx, mediaID)
+}
+
+// Download returns media file content, caller closes file.
+// Original file is returned for empty variant and while variant is not generated yet.
+func (u *mediaUC) Download(ctx context.Context, mediaID uuid.UUID, variant string) (*models.MediaFile, error) {
+	if variant != "" && !models.IsMediaVariant(variant) {
+		return nil, httpErrors.NewBadRequestError(errors.Wrapf(httpErrors.BadQueryParams, "unknown variant %s", variant))
+	}
+
+	m, err := u.mediaRepo.GetByID(ctx, mediaID)
+	if err != nil {
+		return nil, err
+	}
+
+	if variant != "" {
+		v, err := u.mediaRepo.GetVariant(ctx, mediaID, variant)
+		switch {
+		case err == nil:
+			file, err := u.getFile(ctx, v.StorageKey)
+			if err != nil {
+				return nil, err
+			}
+			return &models.MediaFile{File: file, ContentType: v.ContentType, Size: v.Size, Immutable: true}, nil
+		case !errors.Is(err, sql.ErrNoRows):
+			return nil, err
+		}
+	}
+
+	file, err := u.getFile(ctx, m.StorageKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.MediaFile{File: file, ContentType: m.ContentType, Size: m.Size, Immutable: variant == ""}, nil
+}
+
+// ProcessVariants resizes original image into all variants, re-encoding strips EXIF metadata.
+// Media is claimed first, so the same media is never processed by two workers at once.
+func (u *mediaUC) ProcessVariants(ctx context.Context, mediaID uuid.UUID) error {
+	m, err := u.mediaRepo.Claim(ctx, mediaID, time.Second*u.cfg.Media.VariantsStaleAfter)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	// Not resizable images are served as uploaded
+	if !utils.IsResizableImage(m.ContentType) {
+		return u.mediaRepo.UpdateStatus(ctx, mediaID, models.MediaStatusReady)
+	}
+
+	if err = u.generateVariants(ctx, m); err != nil {
+		if statusErr := u.mediaRepo.UpdateStatus(ctx, mediaID, models.MediaStatusFailed); statusErr != nil {
+			u.logger.FromContext(ctx).With("media_id", mediaID).Errorf("mediaUC.ProcessVariants.UpdateStatus: %v", statusErr)
+		}
+		return err
+	}
+
+	return u.mediaRepo.UpdateStatus(ctx, mediaID, models.MediaStatusReady)
+}
+
+// Get ids of media which were not processed in VariantsStaleAfter, fresh ones are still in queue or in progress
+func (u *mediaUC) GetPendingIDs(ctx context.Context, limit int) ([]uuid.UUID, error) {
+	return u.mediaRepo.GetPendingIDs(ctx, time.Second*u.cfg.Media.VariantsStaleAfter, limit)
+}
+
+func (u *mediaUC) generateVariants(ctx context.Context, m *models.Media) error {
+	file, err := u.storage.Get(ctx, m.StorageKey)
+	if err != nil {
+		return errors.Wrap(err, "mediaUC.generateVariants.storage.Get")
+	}
+	data, err := io.ReadAll(io.LimitReader(file, u.cfg.Media.MaxUploadSize+1))
+	file.Close()
+	if err != nil {
+		return errors.Wrap(err, "mediaUC.generateVariants.ReadAll")
+	}
+
+	img, err := imaging.Decode(data, m.ContentType)
+	if err != nil {
+		return errors.Wrap(err, "mediaUC.generateVariants.Decode")
+	}
+
+	for _, spec := range models.MediaVariants {
+		resized := imaging.Resize(img, spec.Width, spec.Height, spec.Crop)
+
+		var buf bytes.Buffer
+		contentType, err := imaging.Encode(&buf, resized)
+		if err != nil {
+			return errors.Wrap(err, "mediaUC.generateVariants.Encode")
+		}
+
+		v := &models.MediaVariant{
+			MediaID:     m.ID,
+			Variant:     spec.Name,
+			ContentType: contentType,
+			Size:        int64(buf.Len()),
+			Width:       resized.Bounds().Dx(),
+			Height:      resized.Bounds().Dy(),
+			StorageKey:  fmt.Sprintf("%s_%s", m.ID, spec.Name),
+		}
+		if err = u.storage.Put(ctx, v.StorageKey, &buf, v.Size, v.ContentType); err != nil {
+			return errors.Wrap(err, "mediaUC.generateVariants.storage.Put")
+		}
+		if _, err = u.mediaRepo.SaveVariant(ctx, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (u *mediaUC) getFile(ctx context.Context, key string) (io.ReadCloser, error) {
+	file, err := u.storage.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, httpErrors.NewNotFoundError(errors.Wrap(err, "mediaUC.getFile.storage.Get"))
+		}
+		return nil, err
+	}
+	return file, nil
+}
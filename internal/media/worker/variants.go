@@ -0,0 +1,111 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/internal/media"
+	"github.com/AliIsmoilov/golang_monolight/pkg/logger"
+)
+
+const sweepBatchSize = 100
+
+// In memory variants queue, lost items are picked up by worker sweep
+type Queue struct {
+	ch chan uuid.UUID
+}
+
+// Variants queue constructor
+func NewQueue(size int) *Queue {
+	return &Queue{ch: make(chan uuid.UUID, size)}
+}
+
+// Enqueue media without blocking, returns false when queue is full
+func (q *Queue) Enqueue(mediaID uuid.UUID) bool {
+	select {
+	case q.ch <- mediaID:
+		return true
+	default:
+		return false
+	}
+}
+
+// Variants worker generates resized images for uploaded media
+type VariantsWorker struct {
+	cfg     *config.Config
+	mediaUC media.UseCase
+	queue   *Queue
+	logger  logger.Logger
+}
+
+// Variants worker constructor
+func NewVariantsWorker(cfg *config.Config, mediaUC media.UseCase, queue *Queue, logger logger.Logger) *VariantsWorker {
+	return &VariantsWorker{cfg: cfg, mediaUC: mediaUC, queue: queue, logger: logger}
+}
+
+// Run processes queued media and sweeps pending media every VariantsSweepInterval until ctx is done
+func (w *VariantsWorker) Run(ctx context.Context) {
+	workers := w.cfg.Media.VariantsWorkers
+	if workers <= 0 {
+		workers = 1
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case mediaID := <-w.queue.ch:
+					w.process(ctx, mediaID)
+				}
+			}
+		}()
+	}
+
+	if w.cfg.Media.VariantsSweepInterval > 0 {
+		ticker := time.NewTicker(time.Second * w.cfg.Media.VariantsSweepInterval)
+		defer ticker.Stop()
+
+		for {
+			w.sweep(ctx)
+
+			select {
+			case <-ctx.Done():
+				wg.Wait()
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+
+	wg.Wait()
+}
+
+func (w *VariantsWorker) process(ctx context.Context, mediaID uuid.UUID) {
+	if err := w.mediaUC.ProcessVariants(ctx, mediaID); err != nil {
+		w.logger.With("media_id", mediaID).Errorf("VariantsWorker.process.ProcessVariants: %v", err)
+	}
+}
+
+// Sweep picks up stale media which were not queued or not finished, e.g. after restart or full queue
+func (w *VariantsWorker) sweep(ctx context.Context) {
+	ids, err := w.mediaUC.GetPendingIDs(ctx, sweepBatchSize)
+	if err != nil {
+		w.logger.Errorf("VariantsWorker.sweep.GetPendingIDs: %v", err)
+		return
+	}
+
+	for _, mediaID := range ids {
+		if !w.queue.Enqueue(mediaID) {
+			return
+		}
+	}
+}
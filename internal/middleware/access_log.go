@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/AliIsmoilov/golang_monolight/pkg/logger"
+	"github.com/AliIsmoilov/golang_monolight/pkg/utils"
+)
+
+// Access log middleware, must run after RequestID middleware.
+// Request id is put into request context, so usecase logs written with logger.FromContext carry it.
+func (mw *MiddlewareManager) AccessLog(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+
+		requestID := utils.GetRequestID(c)
+		ctx := context.WithValue(c.Request().Context(), utils.ReqIDCtxKey{}, requestID)
+		ctx = logger.ContextWithFields(ctx, "request_id", requestID)
+		c.SetRequest(c.Request().WithContext(ctx))
+
+		err := next(c)
+		if err != nil {
+			// Let echo write error response, so logged status is the one sent
+			c.Error(err)
+		}
+
+		status := c.Response().Status
+		log := mw.logger.FromContext(c.Request().Context()).With(utils.RequestLogFields(c)...).With(
+			"status", status,
+			"latency_ms", time.Since(start).Milliseconds(),
+			"bytes", c.Response().Size,
+		)
+		if status >= http.StatusInternalServerError {
+			log.Error("Request")
+		} else {
+			log.Info("Request")
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+	"github.com/AliIsmoilov/golang_monolight/pkg/httpErrors"
+	"github.com/AliIsmoilov/golang_monolight/pkg/logger"
+	"github.com/AliIsmoilov/golang_monolight/pkg/utils"
+)
+
+// JWT way of auth using Authorization header or cookie
+func (mw *MiddlewareManager) AuthJWTMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString, err := mw.getJWTToken(c)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, mw.logger, httpErrors.NewUnauthorizedError(err))
+		}
+
+		if err = mw.validateJWTToken(c, tokenString); err != nil {
+			return utils.ErrResponseWithLog(c, mw.logger, httpErrors.NewUnauthorizedError(err))
+		}
+
+		return next(c)
+	}
+}
+
+// Authenticates request only if it carries usable token, anonymous requests pass through.
+// Malformed, expired or revoked token is ignored, so stale cookie does not lock reader out of public routes.
+func (mw *MiddlewareManager) OptionalAuthJWTMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString, err := mw.getJWTToken(c)
+		if err != nil {
+			return next(c)
+		}
+
+		if err = mw.validateJWTToken(c, tokenString); err != nil {
+			mw.logger.FromContext(c.Request().Context()).Debugf("OptionalAuthJWTMiddleware: anonymous request, %v", err)
+		}
+
+		return next(c)
+	}
+}
+
+// Bearer token from Authorization header takes precedence over cookie
+func (mw *MiddlewareManager) getJWTToken(c echo.Context) (string, error) {
+	if bearerHeader := c.Request().Header.Get(echo.HeaderAuthorization); bearerHeader != "" {
+		headerParts := strings.Split(bearerHeader, " ")
+		if len(headerParts) != 2 || !strings.EqualFold(headerParts[0], "Bearer") {
+			return "", httpErrors.InvalidJWTToken
+		}
+		return headerParts[1], nil
+	}
+
+	cookie, err := c.Cookie(mw.cfg.Server.CookieName)
+	if err != nil || cookie.Value == "" {
+		return "", httpErrors.NoCookie
+	}
+
+	return cookie.Value, nil
+}
+
+func (mw *MiddlewareManager) validateJWTToken(c echo.Context, tokenString string) error {
+	user, sessionID, err := mw.sessionUser(c.Request().Context(), tokenString)
+	if err != nil {
+		return err
+	}
+
+	c.Set("user", user)
+	c.Set("sid", sessionID)
+
+	ctx := context.WithValue(c.Request().Context(), utils.UserCtxKey{}, user)
+	ctx = logger.ContextWithFields(ctx, "user_id", user.ID.String())
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	return nil
+}
+
+// User of token whose session is still active, returns session id as well
+func (mw *MiddlewareManager) sessionUser(ctx context.Context, tokenString string) (*models.User, string, error) {
+	claims, err := utils.ParseJWTToken(tokenString, mw.cfg)
+	if err != nil {
+		return nil, "", err
+	}
+
+	sessionID, err := uuid.Parse(claims.Id)
+	if err != nil {
+		return nil, "", httpErrors.InvalidJWTClaims
+	}
+
+	// Session is checked on every request, so logout revokes access token immediately
+	user, err := mw.authUC.GetBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, "", httpErrors.InvalidJWTToken
+	}
+
+	if user.ID.String() != claims.ID {
+		return nil, "", httpErrors.InvalidJWTClaims
+	}
+
+	return user, claims.Id, nil
+}
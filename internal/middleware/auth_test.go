@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+	"github.com/AliIsmoilov/golang_monolight/pkg/logger"
+	"github.com/AliIsmoilov/golang_monolight/pkg/utils"
+)
+
+func TestMiddlewareManager_OptionalAuthJWTMiddleware(t *testing.T) {
+	t.Parallel()
+
+	level := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	core, _ := observer.New(level)
+	cfg := &config.Config{Server: config.ServerConfig{JwtSecretKey: "secret", AccessTokenExpire: 60, CookieName: "jwt-token"}}
+	mw := NewMiddlewareManager(revokedSessionsUC{}, cfg, config.NewRuntime(cfg), nil, logger.NewCoreLogger(core, level))
+
+	e := echo.New()
+	e.GET("/news/list", func(c echo.Context) error {
+		if _, err := utils.GetUserFromCtx(c.Request().Context()); err == nil {
+			return c.String(http.StatusOK, "user")
+		}
+		return c.String(http.StatusOK, "anonymous")
+	}, mw.OptionalAuthJWTMiddleware)
+
+	request := func(setup func(req *http.Request)) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/news/list", nil)
+		setup(req)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	staleToken, _, err := utils.GenerateJWTToken(&models.User{ID: uuid.New()}, uuid.New(), cfg)
+	require.NoError(t, err)
+
+	for name, setup := range map[string]func(req *http.Request){
+		"No token": func(req *http.Request) {},
+		"Stale cookie": func(req *http.Request) {
+			req.AddCookie(&http.Cookie{Name: cfg.Server.CookieName, Value: staleToken})
+		},
+		"Malformed cookie": func(req *http.Request) {
+			req.AddCookie(&http.Cookie{Name: cfg.Server.CookieName, Value: "not a token"})
+		},
+		"Malformed header": func(req *http.Request) {
+			req.Header.Set(echo.HeaderAuthorization, "Token "+staleToken)
+		},
+	} {
+		rec := request(setup)
+		require.Equal(t, http.StatusOK, rec.Code, name)
+		require.Equal(t, "anonymous", rec.Body.String(), name)
+	}
+}
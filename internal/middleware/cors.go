@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+
+	"github.com/AliIsmoilov/golang_monolight/pkg/csrf"
+)
+
+// Default allowed headers when cors.AllowHeaders is empty
+var defaultCORSHeaders = []string{
+	echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderXRequestID, echo.HeaderAuthorization, csrf.CSRFHeader,
+}
+
+// CORS middleware, allowed origins are read on every request so config reload applies immediately.
+// Listed origins get credentials if enabled, origins allowed only by "*" never do,
+// so auth cookie is not exposed to arbitrary sites.
+func (mw *MiddlewareManager) CORS() echo.MiddlewareFunc {
+	cfg := middleware.CORSConfig{
+		AllowMethods:  mw.cfg.CORS.AllowMethods,
+		AllowHeaders:  mw.cfg.CORS.AllowHeaders,
+		ExposeHeaders: mw.cfg.CORS.ExposeHeaders,
+		MaxAge:        mw.cfg.CORS.MaxAge,
+	}
+	if len(cfg.AllowHeaders) == 0 {
+		cfg.AllowHeaders = defaultCORSHeaders
+	}
+	if len(cfg.ExposeHeaders) == 0 {
+		cfg.ExposeHeaders = []string{csrf.CSRFHeader}
+	}
+
+	listedCfg := cfg
+	listedCfg.AllowOriginFunc = mw.isListedOrigin
+	listedCfg.AllowCredentials = mw.cfg.CORS.AllowCredentials
+	listed := middleware.CORSWithConfig(listedCfg)
+
+	anyCfg := cfg
+	anyCfg.AllowOriginFunc = mw.isAnyOriginAllowed
+	anyOrigin := middleware.CORSWithConfig(anyCfg)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		listedNext, anyNext := listed(next), anyOrigin(next)
+		return func(c echo.Context) error {
+			if ok, _ := mw.isListedOrigin(c.Request().Header.Get(echo.HeaderOrigin)); ok {
+				return listedNext(c)
+			}
+			return anyNext(c)
+		}
+	}
+}
+
+func (mw *MiddlewareManager) isListedOrigin(origin string) (bool, error) {
+	for _, allowed := range mw.runtime.CORSOrigins() {
+		if allowed != "*" && allowed == origin {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (mw *MiddlewareManager) isAnyOriginAllowed(string) (bool, error) {
+	for _, allowed := range mw.runtime.CORSOrigins() {
+		if allowed == "*" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+)
+
+func TestMiddlewareManager_CORS(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{CORS: config.CORSConfig{
+		AllowOrigins:     []string{"https://app.example.com", "*"},
+		AllowCredentials: true,
+		MaxAge:           600,
+	}}
+	mw := NewMiddlewareManager(nil, cfg, config.NewRuntime(cfg), nil, nil)
+
+	e := echo.New()
+	e.Use(mw.CORS())
+	e.GET("/", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	request := func(method, origin string) http.Header {
+		req := httptest.NewRequest(method, "/", nil)
+		req.Header.Set(echo.HeaderOrigin, origin)
+		req.Header.Set(echo.HeaderAccessControlRequestMethod, http.MethodGet)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Header()
+	}
+
+	t.Run("ListedOriginGetsCredentials", func(t *testing.T) {
+		header := request(http.MethodOptions, "https://app.example.com")
+		require.Equal(t, "https://app.example.com", header.Get(echo.HeaderAccessControlAllowOrigin))
+		require.Equal(t, "true", header.Get(echo.HeaderAccessControlAllowCredentials))
+		require.Equal(t, "600", header.Get(echo.HeaderAccessControlMaxAge))
+	})
+
+	t.Run("WildcardOriginWithoutCredentials", func(t *testing.T) {
+		header := request(http.MethodGet, "https://other.example.com")
+		require.NotEmpty(t, header.Get(echo.HeaderAccessControlAllowOrigin))
+		require.Empty(t, header.Get(echo.HeaderAccessControlAllowCredentials))
+	})
+
+	t.Run("ReloadedOrigins", func(t *testing.T) {
+		newCfg := *cfg
+		newCfg.CORS.AllowOrigins = []string{"https://app.example.com"}
+		mw.runtime.Update(&newCfg)
+
+		header := request(http.MethodGet, "https://other.example.com")
+		require.Empty(t, header.Get(echo.HeaderAccessControlAllowOrigin))
+	})
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/AliIsmoilov/golang_monolight/pkg/csrf"
+	"github.com/AliIsmoilov/golang_monolight/pkg/httpErrors"
+	"github.com/AliIsmoilov/golang_monolight/pkg/utils"
+)
+
+// CSRF Middleware, must run after AuthJWTMiddleware as token is tied to session id
+func (mw *MiddlewareManager) CSRF(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !mw.cfg.Server.CSRF {
+			return next(c)
+		}
+
+		token := c.Request().Header.Get(csrf.CSRFHeader)
+		if token == "" {
+			return utils.ErrResponseWithLog(c, mw.logger, httpErrors.NewRestError(http.StatusForbidden, httpErrors.CSRFNotPresented.Error(), nil))
+		}
+
+		sid, ok := c.Get("sid").(string)
+		if !ok {
+			return utils.ErrResponseWithLog(c, mw.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		if err := csrf.ValidateToken(token, sid, mw.cfg.Server.CSRFSalt, mw.logger); err != nil {
+			return utils.ErrResponseWithLog(c, mw.logger, httpErrors.NewRestError(http.StatusForbidden, err.Error(), nil))
+		}
+
+		return next(c)
+	}
+}
@@ -2,17 +2,21 @@ package middleware
 
 import (
 	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/internal/auth"
 	"github.com/AliIsmoilov/golang_monolight/pkg/logger"
+	"github.com/AliIsmoilov/golang_monolight/pkg/ratelimit"
 )
 
 // Middleware manager
 type MiddlewareManager struct {
+	authUC  auth.UseCase
 	cfg     *config.Config
-	origins []string
+	runtime *config.Runtime
+	limiter ratelimit.Store
 	logger  logger.Logger
 }
 
 // Middleware manager constructor
-func NewMiddlewareManager(cfg *config.Config, origins []string, logger logger.Logger) *MiddlewareManager {
-	return &MiddlewareManager{cfg: cfg, origins: origins, logger: logger}
+func NewMiddlewareManager(authUC auth.UseCase, cfg *config.Config, runtime *config.Runtime, limiter ratelimit.Store, logger logger.Logger) *MiddlewareManager {
+	return &MiddlewareManager{authUC: authUC, cfg: cfg, runtime: runtime, limiter: limiter, logger: logger}
 }
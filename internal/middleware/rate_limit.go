@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/AliIsmoilov/golang_monolight/pkg/httpErrors"
+	"github.com/AliIsmoilov/golang_monolight/pkg/ratelimit"
+	"github.com/AliIsmoilov/golang_monolight/pkg/utils"
+)
+
+// Rate limit headers, see IETF RateLimit header fields draft
+const (
+	headerRateLimitLimit     = "RateLimit-Limit"
+	headerRateLimitRemaining = "RateLimit-Remaining"
+	headerRateLimitReset     = "RateLimit-Reset"
+)
+
+// Token bucket rate limit, reads and writes have separate quotas.
+// Authenticated requests are limited per user, anonymous ones per ip.
+// Limiter store failure lets request through, so store outage does not take api down.
+func (mw *MiddlewareManager) RateLimit(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if mw.limiter == nil || skipRateLimit(c) {
+			return next(c)
+		}
+
+		kind, limit := "write", ratelimit.Limit{Requests: mw.cfg.RateLimit.WriteRequests}
+		if isReadMethod(c.Request().Method) {
+			kind, limit = "read", ratelimit.Limit{Requests: mw.cfg.RateLimit.ReadRequests}
+		}
+		limit.Period = time.Second * mw.cfg.RateLimit.Period
+
+		ctx := c.Request().Context()
+		result, err := mw.limiter.Take(ctx, kind+":"+mw.rateLimitKey(c), limit)
+		if err != nil {
+			mw.logger.FromContext(ctx).Errorf("RateLimit.Take: %v", err)
+			return next(c)
+		}
+
+		header := c.Response().Header()
+		header.Set(headerRateLimitLimit, strconv.Itoa(result.Limit))
+		header.Set(headerRateLimitRemaining, strconv.Itoa(result.Remaining))
+		header.Set(headerRateLimitReset, ceilSeconds(result.Reset))
+
+		if !result.Allowed {
+			header.Set(echo.HeaderRetryAfter, ceilSeconds(result.RetryAfter))
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewTooManyRequestsError(nil)))
+		}
+
+		return next(c)
+	}
+}
+
+// Limiter runs before route auth, so session of token is checked here as well.
+// Invalid, expired or revoked token falls back to ip.
+func (mw *MiddlewareManager) rateLimitKey(c echo.Context) string {
+	if tokenString, err := mw.getJWTToken(c); err == nil {
+		if user, _, err := mw.sessionUser(c.Request().Context(), tokenString); err == nil {
+			return "user:" + user.ID.String()
+		}
+	}
+	return "ip:" + utils.GetIPAddress(c)
+}
+
+// Probes, docs and CORS preflights are not limited, CORS middleware answers preflights
+func skipRateLimit(c echo.Context) bool {
+	path := c.Request().URL.Path
+	return c.Request().Method == http.MethodOptions ||
+		strings.HasPrefix(path, "/v1/health") || strings.HasPrefix(path, "/swagger")
+}
+
+func isReadMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/internal/auth"
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+	"github.com/AliIsmoilov/golang_monolight/pkg/ratelimit"
+	"github.com/AliIsmoilov/golang_monolight/pkg/utils"
+)
+
+// Auth use case with revoked sessions only
+type revokedSessionsUC struct {
+	auth.UseCase
+}
+
+func (revokedSessionsUC) GetBySessionID(context.Context, uuid.UUID) (*models.User, error) {
+	return nil, sql.ErrNoRows
+}
+
+func TestMiddlewareManager_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{
+		Server:    config.ServerConfig{JwtSecretKey: "secret", AccessTokenExpire: 60},
+		RateLimit: config.RateLimitConfig{ReadRequests: 1, WriteRequests: 1, Period: 60},
+	}
+	mw := NewMiddlewareManager(revokedSessionsUC{}, cfg, config.NewRuntime(cfg), ratelimit.NewMemoryStore(), nil)
+
+	e := echo.New()
+	e.Use(mw.RateLimit)
+	e.Any("/", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	request := func(method, token string) int {
+		req := httptest.NewRequest(method, "/", nil)
+		if token != "" {
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("PreflightIsNotCounted", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, request(http.MethodOptions, ""))
+		require.Equal(t, http.StatusOK, request(http.MethodOptions, ""))
+		require.Equal(t, http.StatusOK, request(http.MethodGet, ""))
+		require.Equal(t, http.StatusTooManyRequests, request(http.MethodGet, ""))
+	})
+
+	t.Run("RevokedTokenSharesIPBucket", func(t *testing.T) {
+		token, _, err := utils.GenerateJWTToken(&models.User{ID: uuid.New()}, uuid.New(), cfg)
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusOK, request(http.MethodPost, ""))
+		require.Equal(t, http.StatusTooManyRequests, request(http.MethodPost, token))
+	})
+}
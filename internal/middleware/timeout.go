@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Request context timeout, CtxDefaultTimeout is read on every request so config reload applies immediately.
+// Media routes stream files and are limited by server read and write timeouts only.
+func (mw *MiddlewareManager) RequestTimeout(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		timeout := mw.runtime.CtxDefaultTimeout()
+		if timeout <= 0 || strings.HasPrefix(c.Path(), "/v1/media") {
+			return next(c)
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request().Context(), time.Second*timeout)
+		defer cancel()
+		c.SetRequest(c.Request().WithContext(ctx))
+
+		return next(c)
+	}
+}
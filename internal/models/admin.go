@@ -0,0 +1,6 @@
+package models
+
+// Logger level request and response
+type LogLevel struct {
+	Level string `json:"level" validate:"required,oneof=debug info warn error dpanic panic fatal"`
+}
@@ -18,11 +18,21 @@ type BlogsList struct {
 	Page       int     `json:"page"`
 	Size       int     `json:"size"`
 	HasMore    bool    `json:"has_more"`
+	Cursor     string  `json:"cursor,omitempty"`
+	NextCursor string  `json:"next_cursor,omitempty"`
 	Blogs      []*Blog `json:"blogs"`
 }
 
 type Blog struct {
-	ID        uuid.UUID `json:"id" db:"id" validate:"omitempty,uuid"`
-	Title     string    `json:"title" db:"title" validate:"required,gte=3"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	ID          uuid.UUID  `json:"id" db:"id" validate:"omitempty,uuid"`
+	Title       string     `json:"title" db:"title" validate:"required,gte=3"`
+	PublishedBy uuid.UUID  `json:"published_by" db:"published_by"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+	Version     int        `json:"version" db:"version"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+
+	// Full text search result fields, filled only by search
+	Rank     float64 `json:"rank,omitempty" db:"rank"`
+	Headline string  `json:"headline,omitempty" db:"headline"`
 }
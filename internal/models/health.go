@@ -0,0 +1,37 @@
+package models
+
+// Health statuses
+const (
+	HealthAlive    = "alive"
+	HealthReady    = "ready"
+	HealthNotReady = "not_ready"
+	HealthUp       = "up"
+	HealthDown     = "down"
+)
+
+// Readiness response
+type Readiness struct {
+	Status string                  `json:"status"`
+	Checks map[string]*HealthCheck `json:"checks"`
+	Pool   *PoolStats              `json:"pool"`
+}
+
+// Dependency check result
+type HealthCheck struct {
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latency_ms,omitempty"`
+	Error     string `json:"error,omitempty"`
+	Version   *int   `json:"version,omitempty"`
+	Expected  *int   `json:"expected,omitempty"`
+	Dirty     bool   `json:"dirty,omitempty"`
+}
+
+// Postgres connection pool stats
+type PoolStats struct {
+	MaxOpen      int   `json:"max_open"`
+	Open         int   `json:"open"`
+	InUse        int   `json:"in_use"`
+	Idle         int   `json:"idle"`
+	WaitCount    int64 `json:"wait_count"`
+	WaitDuration int64 `json:"wait_duration_ms"`
+}
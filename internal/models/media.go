@@ -0,0 +1,96 @@
+package models
+
+import (
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Media variants processing statuses
+const (
+	MediaStatusPending    = "pending"
+	MediaStatusProcessing = "processing"
+	MediaStatusReady      = "ready"
+	MediaStatusFailed     = "failed"
+)
+
+// Media image variants
+const (
+	VariantThumb = "thumb"
+	VariantCard  = "card"
+	VariantFull  = "full"
+)
+
+// Image variant size, crop fills the whole box
+type VariantSpec struct {
+	Name   string
+	Width  int
+	Height int
+	Crop   bool
+}
+
+// Variants generated for every uploaded image
+var MediaVariants = []VariantSpec{
+	{Name: VariantThumb, Width: 200, Height: 200, Crop: true},
+	{Name: VariantCard, Width: 640, Height: 640},
+	{Name: VariantFull, Width: 1920, Height: 1920},
+}
+
+// Check variant name is known
+func IsMediaVariant(name string) bool {
+	for _, spec := range MediaVariants {
+		if spec.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolved photo urls, original is served as uploaded with metadata stripped
+type PhotoURLs struct {
+	Thumb    string `json:"thumb"`
+	Card     string `json:"card"`
+	Full     string `json:"full"`
+	Original string `json:"original"`
+}
+
+// Uploaded media file
+type Media struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	OwnerID     uuid.UUID `json:"owner_id" db:"owner_id"`
+	FileName    string    `json:"file_name" db:"file_name"`
+	ContentType string    `json:"content_type" db:"content_type"`
+	Size        int64     `json:"size" db:"size"`
+	StorageKey  string    `json:"-" db:"storage_key"`
+	Status      string    `json:"status" db:"status"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// Resized and re-encoded media image, it has no EXIF metadata
+type MediaVariant struct {
+	MediaID     uuid.UUID `json:"media_id" db:"media_id"`
+	Variant     string    `json:"variant" db:"variant"`
+	ContentType string    `json:"content_type" db:"content_type"`
+	Size        int64     `json:"size" db:"size"`
+	Width       int       `json:"width" db:"width"`
+	Height      int       `json:"height" db:"height"`
+	StorageKey  string    `json:"-" db:"storage_key"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// Media upload input, content type is sniffed from file content
+type UploadInput struct {
+	File        io.Reader
+	FileName    string
+	ContentType string
+	Size        int64
+}
+
+// Media file content, immutable files may be cached forever
+type MediaFile struct {
+	File        io.ReadCloser
+	ContentType string
+	Size        int64
+	Immutable   bool
+}
@@ -11,17 +11,24 @@ type NewsSwagger struct {
 	Title       string    `json:"title" db:"title" validate:"required,gte=3"`
 	Description string    `json:"description" db:"description"`
 	Photo       uuid.UUID `json:"photo" db:"photo"`
-	PublishedBy uuid.UUID `json:"published_by" db:"published_by"`
 }
 
 type News struct {
-	ID          uuid.UUID `json:"id" db:"id" validate:"omitempty,uuid"`
-	Title       string    `json:"title" db:"title" validate:"required,gte=3"`
-	Description string    `json:"description" db:"description"`
-	Photo       uuid.UUID `json:"photo" db:"photo"`
-	PublishedBy uuid.UUID `json:"published_by" db:"published_by"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
-	// DeletedAt   time.Time `json:"deleted_at" db:"deleted_at"`
+	ID          uuid.UUID  `json:"id" db:"id" validate:"omitempty,uuid"`
+	Title       string     `json:"title" db:"title" validate:"required,gte=3"`
+	Description string     `json:"description" db:"description"`
+	Photo       uuid.UUID  `json:"photo" db:"photo"`
+	PublishedBy uuid.UUID  `json:"published_by" db:"published_by"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+	Version     int        `json:"version" db:"version"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	PhotoURLs   *PhotoURLs `json:"photo_urls,omitempty" db:"-"`
+
+	// Full text search result fields, filled only by search
+	Rank     float64 `json:"rank,omitempty" db:"rank"`
+	Headline string  `json:"headline,omitempty" db:"headline"`
+	Snippet  string  `json:"snippet,omitempty" db:"snippet"`
 }
 
 // All News response
@@ -31,5 +38,12 @@ type NewsList struct {
 	Page       int     `json:"page"`
 	Size       int     `json:"size"`
 	HasMore    bool    `json:"has_more"`
+	Cursor     string  `json:"cursor,omitempty"`
+	NextCursor string  `json:"next_cursor,omitempty"`
 	News       []*News `json:"news"`
 }
+
+// Purged news response
+type PurgeResult struct {
+	Purged int64 `json:"purged"`
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const minTitleLen = 3
+
+// Field of JSON merge patch (RFC 7396), Set is false for absent field and Null is true for explicit null
+type PatchField[T any] struct {
+	Set   bool
+	Null  bool
+	Value T
+}
+
+// UnmarshalJSON is called only for present fields, null included
+func (f *PatchField[T]) UnmarshalJSON(data []byte) error {
+	f.Set = true
+	if string(data) == "null" {
+		f.Null = true
+		return nil
+	}
+	return json.Unmarshal(data, &f.Value)
+}
+
+// News merge patch, absent fields are kept and null photo removes photo
+type NewsPatch struct {
+	Title       PatchField[string]    `json:"title" swaggertype:"string"`
+	Description PatchField[string]    `json:"description" swaggertype:"string"`
+	Photo       PatchField[uuid.UUID] `json:"photo" swaggertype:"string" format:"uuid"`
+}
+
+// No fields present, patch changes nothing
+func (p *NewsPatch) IsEmpty() bool {
+	return !p.Title.Set && !p.Description.Set && !p.Photo.Set
+}
+
+// Validate present fields, title and description can not be removed
+func (p *NewsPatch) Validate() error {
+	if err := validateTitle(p.Title); err != nil {
+		return err
+	}
+	if p.Description.Set && (p.Description.Null || p.Description.Value == "") {
+		return errors.New("description can not be removed")
+	}
+	return nil
+}
+
+// Blog merge patch, absent fields are kept
+type BlogPatch struct {
+	Title PatchField[string] `json:"title" swaggertype:"string"`
+}
+
+// No fields present, patch changes nothing
+func (p *BlogPatch) IsEmpty() bool {
+	return !p.Title.Set
+}
+
+// Validate present fields, title can not be removed
+func (p *BlogPatch) Validate() error {
+	return validateTitle(p.Title)
+}
+
+func validateTitle(title PatchField[string]) error {
+	if !title.Set {
+		return nil
+	}
+	if title.Null || utf8.RuneCountInString(title.Value) < minTitleLen {
+		return errors.New("title must be at least 3 characters")
+	}
+	return nil
+}
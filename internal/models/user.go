@@ -0,0 +1,142 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Password length after trimming spaces, same as gte=6 of request validation
+const MinPasswordLength = 6
+
+var ErrPasswordTooShort = errors.New("password must be at least 6 characters")
+
+// User roles
+const (
+	RoleAdmin  = "admin"
+	RoleEditor = "editor"
+	RoleAuthor = "author"
+	RoleReader = "reader"
+)
+
+// Check role is one of known roles
+func IsValidRole(role string) bool {
+	switch role {
+	case RoleAdmin, RoleEditor, RoleAuthor, RoleReader:
+		return true
+	}
+	return false
+}
+
+// User Swagger model
+type UserSwagger struct {
+	FirstName string `json:"first_name" validate:"required,lte=30"`
+	LastName  string `json:"last_name" validate:"required,lte=30"`
+	Email     string `json:"email" validate:"required,lte=60,email"`
+	Password  string `json:"password" validate:"required,gte=6"`
+}
+
+// Trim spaces before validation, so blank values do not pass it
+func (r *UserSwagger) Trim() {
+	r.FirstName = strings.TrimSpace(r.FirstName)
+	r.LastName = strings.TrimSpace(r.LastName)
+	r.Email = strings.TrimSpace(r.Email)
+	r.Password = strings.TrimSpace(r.Password)
+}
+
+// User of register request
+func (r *UserSwagger) ToUser() *User {
+	return &User{FirstName: r.FirstName, LastName: r.LastName, Email: r.Email, Password: r.Password}
+}
+
+// Login Swagger model
+type LoginSwagger struct {
+	Email    string `json:"email" validate:"required,lte=60,email"`
+	Password string `json:"password" validate:"required,gte=6"`
+}
+
+// Refresh token request
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// User full model
+type User struct {
+	ID        uuid.UUID `json:"id" db:"id" validate:"omitempty"`
+	FirstName string    `json:"first_name" db:"first_name" validate:"required,lte=30"`
+	LastName  string    `json:"last_name" db:"last_name" validate:"required,lte=30"`
+	Email     string    `json:"email,omitempty" db:"email" validate:"required,lte=60,email"`
+	Password  string    `json:"password,omitempty" db:"password" validate:"required,gte=6"`
+	Role      string    `json:"role" db:"role"`
+	CreatedAt time.Time `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// Hash user password with bcrypt
+func (u *User) HashPassword() error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(u.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	u.Password = string(hashedPassword)
+	return nil
+}
+
+// Compare user password and payload
+func (u *User) ComparePasswords(password string) error {
+	return bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password))
+}
+
+// Sanitize user password
+func (u *User) SanitizePassword() {
+	u.Password = ""
+}
+
+// Prepare user for register, password is checked again after trimming
+func (u *User) PrepareCreate() error {
+	u.Email = strings.ToLower(strings.TrimSpace(u.Email))
+	u.Password = strings.TrimSpace(u.Password)
+	if utf8.RuneCountInString(u.Password) < MinPasswordLength {
+		return ErrPasswordTooShort
+	}
+	if u.Role == "" {
+		u.Role = RoleReader
+	}
+
+	return u.HashPassword()
+}
+
+// Check user has one of given roles
+func (u *User) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if u.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Authors, editors and admins may publish news and blogs
+func (u *User) CanPublish() bool {
+	return u.HasRole(RoleAdmin, RoleEditor, RoleAuthor)
+}
+
+// Only item author, editors and admins may modify published item
+func (u *User) CanModify(publishedBy uuid.UUID) bool {
+	if u.HasRole(RoleAdmin, RoleEditor) {
+		return true
+	}
+	return u.HasRole(RoleAuthor) && u.ID == publishedBy
+}
+
+// User with access and refresh tokens
+type UserWithToken struct {
+	User         *User     `json:"user"`
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
@@ -1,18 +1,37 @@
 package server
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
 	"github.com/AliIsmoilov/golang_monolight/docs"
-	"github.com/AliIsmoilov/golang_monolight/pkg/csrf"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	echoSwagger "github.com/swaggo/echo-swagger"
 
+	adminHttp "github.com/AliIsmoilov/golang_monolight/internal/admin/delivery/http"
+	adminUseCase "github.com/AliIsmoilov/golang_monolight/internal/admin/usecase"
+	authHttp "github.com/AliIsmoilov/golang_monolight/internal/auth/delivery/http"
+	authRepository "github.com/AliIsmoilov/golang_monolight/internal/auth/repository"
+	authUseCase "github.com/AliIsmoilov/golang_monolight/internal/auth/usecase"
+	healthHttp "github.com/AliIsmoilov/golang_monolight/internal/health/delivery/http"
+	healthUseCase "github.com/AliIsmoilov/golang_monolight/internal/health/usecase"
+	mediaHttp "github.com/AliIsmoilov/golang_monolight/internal/media/delivery/http"
+	mediaRepository "github.com/AliIsmoilov/golang_monolight/internal/media/repository"
+	mediaStorage "github.com/AliIsmoilov/golang_monolight/internal/media/storage"
+	mediaUseCase "github.com/AliIsmoilov/golang_monolight/internal/media/usecase"
+	mediaWorker "github.com/AliIsmoilov/golang_monolight/internal/media/worker"
+	apiMiddlewares "github.com/AliIsmoilov/golang_monolight/internal/middleware"
 	todosHttp "github.com/AliIsmoilov/golang_monolight/internal/todos/delivery/http"
 	todosRepository "github.com/AliIsmoilov/golang_monolight/internal/todos/repository"
 	todosUseCase "github.com/AliIsmoilov/golang_monolight/internal/todos/usecase"
+	todosWorker "github.com/AliIsmoilov/golang_monolight/internal/todos/worker"
+	"github.com/AliIsmoilov/golang_monolight/migrations"
+	"github.com/AliIsmoilov/golang_monolight/pkg/db/migrate"
+	"github.com/AliIsmoilov/golang_monolight/pkg/ratelimit"
+	"github.com/AliIsmoilov/golang_monolight/pkg/tracing"
+	"github.com/AliIsmoilov/golang_monolight/pkg/utils"
 )
 
 // @title Swagger Example API
@@ -21,14 +40,53 @@ import (
 // @host localhost:8080
 // @BasePath /api/v1
 func (s *Server) MapHandlers(e *echo.Echo) error {
+	ipExtractor, err := utils.NewIPExtractor(s.cfg.Server.TrustedProxies)
+	if err != nil {
+		return err
+	}
+	e.IPExtractor = ipExtractor
 
 	// Init repositories
+	aRepo := authRepository.NewAuthRepository(s.db)
+	authUC := authUseCase.NewAuthUseCase(s.cfg, aRepo, s.logger)
+	authHandlers := authHttp.NewAuthHandlers(s.cfg, authUC, s.logger)
+
+	if s.cfg.RateLimit.Enabled {
+		limiter, err := ratelimit.NewStore(s.cfg)
+		if err != nil {
+			return err
+		}
+		s.limiter = limiter
+	}
+	mw := apiMiddlewares.NewMiddlewareManager(authUC, s.cfg, s.runtime, s.limiter, s.logger)
+
 	cRepo := todosRepository.NewToDosRepository(s.db)
-	commUC := todosUseCase.NewToDosUseCase(s.cfg, cRepo, s.logger)
+	commUC := todosUseCase.NewToDosUseCase(s.cfg, cRepo, s.metrics, s.logger)
+
+	storage, err := mediaStorage.NewStorage(context.Background(), s.cfg)
+	if err != nil {
+		return err
+	}
+	mRepo := mediaRepository.NewMediaRepository(s.db)
+	variantsQueue := mediaWorker.NewQueue(s.cfg.Media.VariantsQueueSize)
+	mediaUC := mediaUseCase.NewMediaUseCase(s.cfg, mRepo, storage, variantsQueue, s.logger)
+	s.RegisterWorker("media variants", mediaWorker.NewVariantsWorker(s.cfg, mediaUC, variantsQueue, s.logger))
+	mediaHandlers := mediaHttp.NewMediaHandlers(s.cfg, mediaUC, s.logger)
 
 	nRepo := todosRepository.NewNewsRepository(s.db)
-	newsUC := todosUseCase.NewNewsUseCase(s.cfg, nRepo, s.logger)
+	newsUC := todosUseCase.NewNewsUseCase(s.cfg, nRepo, mRepo, s.metrics, s.logger)
 	newsHandlers := todosHttp.NewNewsHandlers(s.cfg, newsUC, s.logger)
+	s.RegisterWorker("trash purger", todosWorker.NewTrashPurger(s.cfg, nRepo, cRepo, s.logger))
+
+	adminUC := adminUseCase.NewAdminUseCase(s.cfg, s.logger)
+	adminHandlers := adminHttp.NewAdminHandlers(s.cfg, adminUC, s.logger)
+
+	migrator, err := migrate.NewMigrator(s.db, migrations.FS)
+	if err != nil {
+		return err
+	}
+	s.healthUC = healthUseCase.NewHealthUseCase(s.cfg, s.db, migrator, s.logger)
+	healthHandlers := healthHttp.NewHealthHandlers(s.cfg, s.healthUC, s.logger)
 
 	// Init handlers
 	todoHandlers := todosHttp.NewBlogHandlers(s.cfg, commUC, s.logger)
@@ -41,16 +99,19 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 	// e.Start(":5050")
 
-	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins: []string{"*"},
-		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderXRequestID, csrf.CSRFHeader},
-	}))
+	// Access log wraps recover, so recovered panics are logged with 500 status
+	e.Use(middleware.RequestID())
+	e.Use(mw.AccessLog)
+	e.Use(s.metrics.Middleware())
+	e.Use(tracing.Middleware())
 	e.Use(middleware.RecoverWithConfig(middleware.RecoverConfig{
 		StackSize:         1 << 10, // 1 KB
 		DisablePrintStack: true,
 		DisableStackAll:   true,
 	}))
-	e.Use(middleware.RequestID())
+	e.Use(mw.CORS())
+	e.Use(mw.RateLimit)
+	e.Use(mw.RequestTimeout)
 
 	e.Use(middleware.GzipWithConfig(middleware.GzipConfig{
 		Level: 5,
@@ -59,20 +120,33 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 		},
 	}))
 	e.Use(middleware.Secure())
-	e.Use(middleware.BodyLimit("2M"))
+	e.Use(middleware.BodyLimitWithConfig(middleware.BodyLimitConfig{
+		Limit: "2M",
+		// Media upload has its own limit from config
+		Skipper: func(c echo.Context) bool {
+			return c.Request().Method == http.MethodPost && c.Path() == "/v1/media"
+		},
+	}))
 
 	v1 := e.Group("/v1")
 
-	health := v1.Group("/health")
+	healthGroup := v1.Group("/health")
+	healthHttp.MapHealthRoutes(healthGroup, healthHandlers)
+
+	authGroup := v1.Group("/auth")
+	authHttp.MapAuthRoutes(authGroup, authHandlers, mw)
+
 	todoGroup := v1.Group("/blogs")
-	todosHttp.MapToDosRoutes(todoGroup, todoHandlers)
+	todosHttp.MapToDosRoutes(todoGroup, todoHandlers, mw)
 
 	newsGroup := v1.Group("/news")
-	todosHttp.MapNewsRoutes(newsGroup, newsHandlers)
+	todosHttp.MapNewsRoutes(newsGroup, newsHandlers, mw)
+
+	mediaGroup := v1.Group("/media")
+	mediaHttp.MapMediaRoutes(mediaGroup, mediaHandlers, mw, s.cfg)
 
-	health.GET("", func(c echo.Context) error {
-		return c.JSON(http.StatusOK, map[string]string{"status": "healthy!"})
-	})
+	adminGroup := v1.Group("/admin")
+	adminHttp.MapAdminRoutes(adminGroup, adminHandlers, mw)
 
 	return nil
 }
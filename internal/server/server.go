@@ -2,6 +2,8 @@ package server
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	_ "net/http/pprof"
 	"os"
@@ -13,28 +15,56 @@ import (
 	"github.com/labstack/echo/v4"
 
 	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/internal/health"
 	"github.com/AliIsmoilov/golang_monolight/pkg/logger"
+	"github.com/AliIsmoilov/golang_monolight/pkg/metrics"
+	"github.com/AliIsmoilov/golang_monolight/pkg/ratelimit"
 )
 
-const (
-	maxHeaderBytes = 1 << 20
-	ctxTimeout     = 5
-)
+const maxHeaderBytes = 1 << 20
 
 // Server struct
 type Server struct {
-	echo   *echo.Echo
-	cfg    *config.Config
-	db     *sqlx.DB
-	logger logger.Logger
+	echo     *echo.Echo
+	cfg      *config.Config
+	runtime  *config.Runtime
+	db       *sqlx.DB
+	metrics  *metrics.Metrics
+	logger   logger.Logger
+	healthUC health.UseCase
+	limiter  ratelimit.Store
+	workers  []*runningWorker
 }
 
 // NewServer constructor
 func NewServer(cfg *config.Config, db *sqlx.DB, logger logger.Logger) *Server {
-	return &Server{echo: echo.New(), cfg: cfg, db: db, logger: logger}
+	m := metrics.NewMetrics()
+	m.RegisterDB(db.DB, "postgres")
+	return &Server{echo: echo.New(), cfg: cfg, runtime: config.NewRuntime(cfg), db: db, metrics: m, logger: logger}
+}
+
+// Reload applies safe settings from changed config file, other settings need restart.
+// Logger level is changed only when it differs in file, so level set through admin api survives unrelated edits.
+func (s *Server) Reload(cfg *config.Config) {
+	levelChanged := cfg.Logger.Level != s.runtime.LogLevel()
+	s.runtime.Update(cfg)
+
+	if levelChanged {
+		if err := s.logger.SetLevel(cfg.Logger.Level); err != nil {
+			s.logger.Errorf("Server.Reload.SetLevel: %v", err)
+		}
+	}
+	s.logger.Infof("Config reloaded, LogLevel: %s, CtxDefaultTimeout: %d, CORS origins: %v",
+		s.logger.Level(), s.runtime.CtxDefaultTimeout(), s.runtime.CORSOrigins())
 }
 
+// Run registers routes, starts listeners and workers and blocks until SIGINT or SIGTERM or listener failure.
+// On shutdown readiness fails first, then listeners are drained within grace period and workers are stopped.
 func (s *Server) Run() error {
+	if err := s.MapHandlers(s.echo); err != nil {
+		return err
+	}
+
 	server := &http.Server{
 		Addr:           s.cfg.Server.Port,
 		ReadTimeout:    time.Second * s.cfg.Server.ReadTimeout,
@@ -42,32 +72,81 @@ func (s *Server) Run() error {
 		MaxHeaderBytes: maxHeaderBytes,
 	}
 
+	// Debug listener serves pprof registered in default mux and metrics
+	debugMux := http.NewServeMux()
+	debugMux.Handle("/debug/pprof/", http.DefaultServeMux)
+	debugMux.Handle("/metrics", s.metrics.Handler())
+	debugServer := &http.Server{
+		Addr:              s.cfg.Server.PprofPort,
+		Handler:           debugMux,
+		ReadHeaderTimeout: time.Second * s.cfg.Server.ReadTimeout,
+		MaxHeaderBytes:    maxHeaderBytes,
+	}
+
+	listenErr := make(chan error, 2)
 	go func() {
 		s.logger.Infof("Server is listening on PORT: %s", s.cfg.Server.Port)
-		if err := s.echo.StartServer(server); err != nil {
-			s.logger.Fatalf("Error starting Server: ", err)
+		if err := s.echo.StartServer(server); !errors.Is(err, http.ErrServerClosed) {
+			listenErr <- fmt.Errorf("Server.Run.StartServer: %w", err)
 		}
 	}()
-
 	go func() {
 		s.logger.Infof("Starting Debug Server on PORT: %s", s.cfg.Server.PprofPort)
-		if err := http.ListenAndServe(s.cfg.Server.PprofPort, http.DefaultServeMux); err != nil {
-			s.logger.Errorf("Error PPROF ListenAndServe: %s", err)
+		if err := debugServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			listenErr <- fmt.Errorf("Server.Run.DebugListenAndServe: %w", err)
 		}
 	}()
 
-	if err := s.MapHandlers(s.echo); err != nil {
-		return err
-	}
+	s.startWorkers()
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(quit)
+
+	var runErr error
+	select {
+	case sig := <-quit:
+		s.logger.Infof("Received %s, shutting down", sig)
+	case runErr = <-listenErr:
+		s.logger.Errorf("Listener failed, shutting down: %v", runErr)
+	}
 
-	<-quit
+	// Readiness fails first, so load balancer stops routing before listener is closed
+	s.healthUC.MarkShuttingDown()
+	if delay := s.cfg.Server.ReadinessDrainDelay; runErr == nil && delay > 0 {
+		s.logger.Infof("Draining for %d seconds before shutdown", delay)
+		time.Sleep(time.Second * delay)
+	}
 
-	ctx, shutdown := context.WithTimeout(context.Background(), ctxTimeout*time.Second)
-	defer shutdown()
+	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout())
+	defer cancel()
+
+	errs := []error{runErr}
+	if err := server.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("Server.Run.Shutdown: %w", err))
+	}
+	if err := debugServer.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("Server.Run.DebugShutdown: %w", err))
+	}
+	errs = append(errs, s.stopWorkers(ctx))
+	if s.limiter != nil {
+		if err := s.limiter.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("Server.Run.LimiterClose: %w", err))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
 
 	s.logger.Info("Server Exited Properly")
-	return s.echo.Server.Shutdown(ctx)
+	return nil
+}
+
+// Grace period for in-flight requests and workers, defaults to request timeout so running requests may finish
+func (s *Server) shutdownTimeout() time.Duration {
+	if s.cfg.Server.ShutdownTimeout > 0 {
+		return time.Second * s.cfg.Server.ShutdownTimeout
+	}
+	return time.Second * s.runtime.CtxDefaultTimeout()
 }
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"context"
+	"fmt"
+)
+
+// Background worker, Run must return when ctx is done
+type Worker interface {
+	Run(ctx context.Context)
+}
+
+type runningWorker struct {
+	name   string
+	worker Worker
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// RegisterWorker adds worker started by Run, workers are stopped in reverse registration order
+func (s *Server) RegisterWorker(name string, w Worker) {
+	s.workers = append(s.workers, &runningWorker{name: name, worker: w})
+}
+
+func (s *Server) startWorkers() {
+	for _, w := range s.workers {
+		ctx, cancel := context.WithCancel(context.Background())
+		w.cancel, w.done = cancel, make(chan struct{})
+
+		go func(w *runningWorker) {
+			defer close(w.done)
+			w.worker.Run(ctx)
+		}(w)
+		s.logger.Infof("Worker %s started", w.name)
+	}
+}
+
+// Stop workers one by one, so worker may rely on ones registered before it until it exits
+func (s *Server) stopWorkers(ctx context.Context) error {
+	for i := len(s.workers) - 1; i >= 0; i-- {
+		w := s.workers[i]
+		if w.cancel == nil {
+			continue
+		}
+
+		w.cancel()
+		select {
+		case <-w.done:
+			s.logger.Infof("Worker %s stopped", w.name)
+		case <-ctx.Done():
+			return fmt.Errorf("Server.stopWorkers: worker %s did not stop: %w", w.name, ctx.Err())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/pkg/logger"
+)
+
+type recordingWorker struct {
+	name    string
+	mu      *sync.Mutex
+	stopped *[]string
+}
+
+func (w *recordingWorker) Run(ctx context.Context) {
+	<-ctx.Done()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	*w.stopped = append(*w.stopped, w.name)
+}
+
+type stuckWorker struct{}
+
+func (stuckWorker) Run(context.Context) { select {} }
+
+func TestServer_StopWorkers(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{Logger: config.Logger{Level: "fatal"}}
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+
+	t.Run("ReverseOrder", func(t *testing.T) {
+		var (
+			mu      sync.Mutex
+			stopped []string
+		)
+		s := &Server{cfg: cfg, logger: apiLogger}
+		s.RegisterWorker("first", &recordingWorker{name: "first", mu: &mu, stopped: &stopped})
+		s.RegisterWorker("second", &recordingWorker{name: "second", mu: &mu, stopped: &stopped})
+		s.startWorkers()
+
+		require.NoError(t, s.stopWorkers(context.Background()))
+		require.Equal(t, []string{"second", "first"}, stopped)
+	})
+
+	t.Run("GracePeriodExceeded", func(t *testing.T) {
+		s := &Server{cfg: cfg, logger: apiLogger}
+		s.RegisterWorker("stuck", stuckWorker{})
+		s.startWorkers()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		err := s.stopWorkers(ctx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorContains(t, err, "stuck")
+	})
+}
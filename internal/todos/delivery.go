@@ -6,17 +6,28 @@ import "github.com/labstack/echo/v4"
 type Handlers interface {
 	Create() echo.HandlerFunc
 	Update() echo.HandlerFunc
+	Patch() echo.HandlerFunc
 	Delete() echo.HandlerFunc
+	SoftDelete() echo.HandlerFunc
+	Restore() echo.HandlerFunc
 	GetByID() echo.HandlerFunc
 	GetAll() echo.HandlerFunc
+	GetTrash() echo.HandlerFunc
+	Purge() echo.HandlerFunc
+	Search() echo.HandlerFunc
 }
 
 // News HTTP Handlers interface
 type NewsHandlers interface {
 	Create() echo.HandlerFunc
 	Update() echo.HandlerFunc
+	Patch() echo.HandlerFunc
 	Delete() echo.HandlerFunc
 	SoftDelete() echo.HandlerFunc
+	Restore() echo.HandlerFunc
 	GetByID() echo.HandlerFunc
 	GetAll() echo.HandlerFunc
+	GetTrash() echo.HandlerFunc
+	Purge() echo.HandlerFunc
+	Search() echo.HandlerFunc
 }
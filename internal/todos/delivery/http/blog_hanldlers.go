@@ -2,6 +2,8 @@ package http
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -34,6 +36,8 @@ func NewBlogHandlers(cfg *config.Config, todosUC todos.UseCase, logger logger.Lo
 // @Produce  json
 // @Param body body models.BlogSwagger true "body"
 // @Success 201 {object} models.Blog
+// @Failure 401 {object} httpErrors.RestErr
+// @Failure 403 {object} httpErrors.RestErr
 // @Failure 500 {object} httpErrors.RestErr
 // @Router /blogs [post]
 func (h *blogHandlers) Create() echo.HandlerFunc {
@@ -51,6 +55,7 @@ func (h *blogHandlers) Create() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
+		c.Response().Header().Set(utils.HeaderETag, utils.VersionETag(createdBlog.Version))
 		return c.JSON(http.StatusCreated, createdBlog)
 	}
 }
@@ -63,7 +68,12 @@ func (h *blogHandlers) Create() echo.HandlerFunc {
 // @Produce  json
 // @Param id path string true "id"
 // @Param body body models.BlogSwagger true "body"
-// @Success 200 {object} models.BlogSwagger
+// @Param If-Match header string false "ETag from GetByID, stale version is rejected with 412"
+// @Success 200 {object} models.Blog
+// @Header 200 {string} ETag "version of updated blog"
+// @Failure 401 {object} httpErrors.RestErr
+// @Failure 403 {object} httpErrors.RestErr
+// @Failure 412 {object} httpErrors.RestErr
 // @Failure 500 {object} httpErrors.RestErr
 // @Router /blogs/{id} [put]
 func (h *blogHandlers) Update() echo.HandlerFunc {
@@ -81,19 +91,74 @@ func (h *blogHandlers) Update() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
+		version, err := utils.IfMatchVersion(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
 		updatedToDo, err := h.todosUC.Update(c.Request().Context(), &models.Blog{
-			ID:    blogsID,
-			Title: comm.Title,
+			ID:      blogsID,
+			Title:   comm.Title,
+			Version: version,
 		})
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
+		c.Response().Header().Set(utils.HeaderETag, utils.VersionETag(updatedToDo.Version))
 		return c.JSON(http.StatusOK, updatedToDo)
 	}
 }
 
+// Patch
+// @Summary Patch blog
+// @Description partial update with JSON merge patch (RFC 7396), only present fields are changed
+// @Tags Blog
+// @Accept  application/merge-patch+json,json
+// @Produce  json
+// @Param id path string true "id"
+// @Param body body models.BlogPatch true "merge patch"
+// @Param If-Match header string false "ETag from GetByID, stale version is rejected with 412"
+// @Success 200 {object} models.Blog
+// @Header 200 {string} ETag "version of patched blog"
+// @Failure 400 {object} httpErrors.RestErr
+// @Failure 401 {object} httpErrors.RestErr
+// @Failure 403 {object} httpErrors.RestErr
+// @Failure 404 {object} httpErrors.RestErr
+// @Failure 412 {object} httpErrors.RestErr
+// @Failure 500 {object} httpErrors.RestErr
+// @Router /blogs/{id} [patch]
+func (h *blogHandlers) Patch() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		patch := &models.BlogPatch{}
+		if err = utils.ReadMergePatch(c, patch); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		version, err := utils.IfMatchVersion(c)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		patched, err := h.todosUC.Patch(c.Request().Context(), id, patch, version)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		c.Response().Header().Set(utils.HeaderETag, utils.VersionETag(patched.Version))
+		return c.JSON(http.StatusOK, patched)
+	}
+}
+
 // Delete
 // @Summary Delete blog
 // @Description delete blog
@@ -102,6 +167,8 @@ func (h *blogHandlers) Update() echo.HandlerFunc {
 // @Produce  json
 // @Param id path string true "id"
 // @Success 200 {string} string	"ok"
+// @Failure 401 {object} httpErrors.RestErr
+// @Failure 403 {object} httpErrors.RestErr
 // @Failure 500 {object} httpErrors.RestErr
 // @Router /blogs/{id} [delete]
 func (h *blogHandlers) Delete() echo.HandlerFunc {
@@ -122,14 +189,83 @@ func (h *blogHandlers) Delete() echo.HandlerFunc {
 	}
 }
 
+// Soft Delete
+// @Summary Soft Delete blog
+// @Description soft delete blog
+// @Tags Blog
+// @Accept  json
+// @Produce  json
+// @Param id path string true "id"
+// @Success 200 {string} string	"ok"
+// @Failure 401 {object} httpErrors.RestErr
+// @Failure 403 {object} httpErrors.RestErr
+// @Failure 404 {object} httpErrors.RestErr
+// @Failure 500 {object} httpErrors.RestErr
+// @Router /blogs/soft/{id} [delete]
+func (h *blogHandlers) SoftDelete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		blogsID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.todosUC.SoftDelete(c.Request().Context(), blogsID); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+// Restore
+// @Summary Restore blog
+// @Description restore soft deleted blog
+// @Tags Blog
+// @Accept  json
+// @Produce  json
+// @Param id path string true "id"
+// @Success 200 {object} models.Blog
+// @Failure 401 {object} httpErrors.RestErr
+// @Failure 403 {object} httpErrors.RestErr
+// @Failure 404 {object} httpErrors.RestErr
+// @Failure 500 {object} httpErrors.RestErr
+// @Router /blogs/{id}/restore [post]
+func (h *blogHandlers) Restore() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		blogsID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		restoredBlog, err := h.todosUC.Restore(c.Request().Context(), blogsID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, restoredBlog)
+	}
+}
+
 // GetByID
 // @Summary Get blog
-// @Description Get blog by id
+// @Description Get blog by id, soft deleted blogs are not found unless include_deleted is set
 // @Tags Blog
 // @Accept  json
 // @Produce  json
 // @Param id path string true "id"
+// @Param include_deleted query bool false "include soft deleted blog, editors only"
+// @Param If-None-Match header string false "ETag from previous response"
 // @Success 200 {object} models.Blog
+// @Header 200 {string} ETag "blog version"
+// @Success 304 "not modified"
+// @Failure 400 {object} httpErrors.RestErr
+// @Failure 404 {object} httpErrors.RestErr
 // @Failure 500 {object} httpErrors.RestErr
 // @Router /blogs/{id} [get]
 func (h *blogHandlers) GetByID() echo.HandlerFunc {
@@ -141,12 +277,25 @@ func (h *blogHandlers) GetByID() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		blog, err := h.todosUC.GetByID(c.Request().Context(), blogsID)
+		includeDeleted := false
+		if v := c.QueryParam("include_deleted"); v != "" {
+			if includeDeleted, err = strconv.ParseBool(v); err != nil {
+				return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewBadRequestError(httpErrors.BadQueryParams))
+			}
+		}
+
+		blog, err := h.todosUC.GetByID(c.Request().Context(), blogsID, includeDeleted)
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
+		etag := utils.VersionETag(blog.Version)
+		c.Response().Header().Set(utils.HeaderETag, etag)
+		if utils.IfNoneMatch(c, etag) {
+			return c.NoContent(http.StatusNotModified)
+		}
+
 		return c.JSON(http.StatusOK, blog)
 	}
 }
@@ -157,10 +306,17 @@ func (h *blogHandlers) GetByID() echo.HandlerFunc {
 // @Tags Blog
 // @Accept  json
 // @Produce  json
-// @Param title query string false "title"
+// @Param title query string false "title contains, case insensitive"
+// @Param published_by query string false "publisher id" Format(uuid)
+// @Param created_from query string false "created at or after, RFC3339 or YYYY-MM-DD"
+// @Param created_to query string false "created at or before, RFC3339 or YYYY-MM-DD (whole day)"
+// @Param include_deleted query bool false "include soft deleted blogs, editors only"
 // @Param page query int false "page number" Format(page)
 // @Param size query int false "number of elements per page" Format(size)
+// @Param orderBy query string false "comma separated sort fields, '-' prefix for descending: -created_at,title"
+// @Param cursor query string false "opaque cursor from next_cursor, presence switches to cursor pagination, empty for first page"
 // @Success 200 {object} models.BlogsList
+// @Failure 400 {object} httpErrors.RestErr
 // @Failure 500 {object} httpErrors.RestErr
 // @Router /blogs/list [get]
 func (h *blogHandlers) GetAll() echo.HandlerFunc {
@@ -172,7 +328,13 @@ func (h *blogHandlers) GetAll() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		toDoList, err := h.todosUC.GetAll(c.Request().Context(), c.QueryParam("title"), pq)
+		filter, err := utils.GetListFilterFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		toDoList, err := h.todosUC.GetAll(c.Request().Context(), filter, pq)
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
@@ -181,3 +343,108 @@ func (h *blogHandlers) GetAll() echo.HandlerFunc {
 		return c.JSON(http.StatusOK, toDoList)
 	}
 }
+
+// GetTrash
+// @Summary Get Blog trash
+// @Description Get soft deleted blogs, recently deleted first. Authors see only their own blogs
+// @Tags Blog
+// @Accept  json
+// @Produce  json
+// @Param title query string false "title contains, case insensitive"
+// @Param published_by query string false "publisher id" Format(uuid)
+// @Param created_from query string false "created at or after, RFC3339 or YYYY-MM-DD"
+// @Param created_to query string false "created at or before, RFC3339 or YYYY-MM-DD (whole day)"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Success 200 {object} models.BlogsList
+// @Failure 400 {object} httpErrors.RestErr
+// @Failure 401 {object} httpErrors.RestErr
+// @Failure 403 {object} httpErrors.RestErr
+// @Failure 500 {object} httpErrors.RestErr
+// @Router /blogs/trash [get]
+func (h *blogHandlers) GetTrash() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		pq, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		filter, err := utils.GetListFilterFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		blogsList, err := h.todosUC.GetTrash(c.Request().Context(), filter, pq)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, blogsList)
+	}
+}
+
+// Purge
+// @Summary Purge Blog trash
+// @Description hard delete blogs which stayed in trash longer than retention, admins only
+// @Tags Blog
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} models.PurgeResult
+// @Failure 401 {object} httpErrors.RestErr
+// @Failure 403 {object} httpErrors.RestErr
+// @Failure 409 {object} httpErrors.RestErr
+// @Failure 500 {object} httpErrors.RestErr
+// @Router /blogs/trash [delete]
+func (h *blogHandlers) Purge() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		purged, err := h.todosUC.Purge(c.Request().Context())
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, &models.PurgeResult{Purged: purged})
+	}
+}
+
+// Search
+// @Summary Search Blog
+// @Description Full text search by title, results are ranked by relevance
+// @Tags Blog
+// @Accept  json
+// @Produce  json
+// @Param q query string true "search text"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Success 200 {object} models.BlogsList
+// @Failure 400 {object} httpErrors.RestErr
+// @Failure 500 {object} httpErrors.RestErr
+// @Router /blogs/search [get]
+func (h *blogHandlers) Search() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		text := strings.TrimSpace(c.QueryParam("q"))
+		if text == "" {
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewBadRequestError(httpErrors.BadQueryParams))
+		}
+
+		pq, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		blogsList, err := h.todosUC.Search(c.Request().Context(), text, pq)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, blogsList)
+	}
+}
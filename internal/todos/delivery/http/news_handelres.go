@@ -2,6 +2,8 @@ package http
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -34,6 +36,8 @@ func NewNewsHandlers(cfg *config.Config, newsUC todos.NewsUseCase, logger logger
 // @Produce  json
 // @Param body body models.NewsSwagger true "body"
 // @Success 201 {object} models.News
+// @Failure 401 {object} httpErrors.RestErr
+// @Failure 403 {object} httpErrors.RestErr
 // @Failure 500 {object} httpErrors.RestErr
 // @Router /news [post]
 func (h *newsHandlers) Create() echo.HandlerFunc {
@@ -51,6 +55,7 @@ func (h *newsHandlers) Create() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
+		c.Response().Header().Set(utils.HeaderETag, utils.VersionETag(createdBlog.Version))
 		return c.JSON(http.StatusCreated, createdBlog)
 	}
 }
@@ -63,7 +68,12 @@ func (h *newsHandlers) Create() echo.HandlerFunc {
 // @Produce  json
 // @Param id path string true "id"
 // @Param body body models.NewsSwagger true "body"
-// @Success 200 {object} models.NewsSwagger
+// @Param If-Match header string false "ETag from GetByID, stale version is rejected with 412"
+// @Success 200 {object} models.News
+// @Header 200 {string} ETag "version of updated news"
+// @Failure 401 {object} httpErrors.RestErr
+// @Failure 403 {object} httpErrors.RestErr
+// @Failure 412 {object} httpErrors.RestErr
 // @Failure 500 {object} httpErrors.RestErr
 // @Router /news/{id} [put]
 func (h *newsHandlers) Update() echo.HandlerFunc {
@@ -81,22 +91,76 @@ func (h *newsHandlers) Update() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
+		version, err := utils.IfMatchVersion(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
 		updatedNews, err := h.newsUC.Update(c.Request().Context(), &models.News{
 			ID:          newsID,
 			Title:       comm.Title,
 			Description: comm.Description,
 			Photo:       comm.Photo,
-			PublishedBy: comm.PublishedBy,
+			Version:     version,
 		})
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
+		c.Response().Header().Set(utils.HeaderETag, utils.VersionETag(updatedNews.Version))
 		return c.JSON(http.StatusOK, updatedNews)
 	}
 }
 
+// Patch
+// @Summary Patch news
+// @Description partial update with JSON merge patch (RFC 7396), only present fields are changed, null photo removes photo
+// @Tags News
+// @Accept  application/merge-patch+json,json
+// @Produce  json
+// @Param id path string true "id"
+// @Param body body models.NewsPatch true "merge patch"
+// @Param If-Match header string false "ETag from GetByID, stale version is rejected with 412"
+// @Success 200 {object} models.News
+// @Header 200 {string} ETag "version of patched news"
+// @Failure 400 {object} httpErrors.RestErr
+// @Failure 401 {object} httpErrors.RestErr
+// @Failure 403 {object} httpErrors.RestErr
+// @Failure 404 {object} httpErrors.RestErr
+// @Failure 412 {object} httpErrors.RestErr
+// @Failure 500 {object} httpErrors.RestErr
+// @Router /news/{id} [patch]
+func (h *newsHandlers) Patch() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		patch := &models.NewsPatch{}
+		if err = utils.ReadMergePatch(c, patch); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		version, err := utils.IfMatchVersion(c)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		patched, err := h.newsUC.Patch(c.Request().Context(), id, patch, version)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		c.Response().Header().Set(utils.HeaderETag, utils.VersionETag(patched.Version))
+		return c.JSON(http.StatusOK, patched)
+	}
+}
+
 // Delete
 // @Summary Delete news
 // @Description delete news
@@ -105,6 +169,8 @@ func (h *newsHandlers) Update() echo.HandlerFunc {
 // @Produce  json
 // @Param id path string true "id"
 // @Success 200 {string} string	"ok"
+// @Failure 401 {object} httpErrors.RestErr
+// @Failure 403 {object} httpErrors.RestErr
 // @Failure 500 {object} httpErrors.RestErr
 // @Router /news/{id} [delete]
 func (h *newsHandlers) Delete() echo.HandlerFunc {
@@ -127,12 +193,18 @@ func (h *newsHandlers) Delete() echo.HandlerFunc {
 
 // GetByID
 // @Summary Get news
-// @Description Get news by id
+// @Description Get news by id, soft deleted news are not found unless include_deleted is set
 // @Tags News
 // @Accept  json
 // @Produce  json
 // @Param id path string true "id"
+// @Param include_deleted query bool false "include soft deleted news, editors only"
+// @Param If-None-Match header string false "ETag from previous response"
 // @Success 200 {object} models.News
+// @Header 200 {string} ETag "news version"
+// @Success 304 "not modified"
+// @Failure 400 {object} httpErrors.RestErr
+// @Failure 404 {object} httpErrors.RestErr
 // @Failure 500 {object} httpErrors.RestErr
 // @Router /news/{id} [get]
 func (h *newsHandlers) GetByID() echo.HandlerFunc {
@@ -144,12 +216,25 @@ func (h *newsHandlers) GetByID() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		news, err := h.newsUC.GetByID(c.Request().Context(), newsID)
+		includeDeleted := false
+		if v := c.QueryParam("include_deleted"); v != "" {
+			if includeDeleted, err = strconv.ParseBool(v); err != nil {
+				return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewBadRequestError(httpErrors.BadQueryParams))
+			}
+		}
+
+		news, err := h.newsUC.GetByID(c.Request().Context(), newsID, includeDeleted)
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
+		etag := utils.VersionETag(news.Version)
+		c.Response().Header().Set(utils.HeaderETag, etag)
+		if utils.IfNoneMatch(c, etag) {
+			return c.NoContent(http.StatusNotModified)
+		}
+
 		return c.JSON(http.StatusOK, news)
 	}
 }
@@ -160,10 +245,18 @@ func (h *newsHandlers) GetByID() echo.HandlerFunc {
 // @Tags News
 // @Accept  json
 // @Produce  json
-// @Param title query string false "title"
+// @Param title query string false "title contains, case insensitive"
+// @Param published_by query string false "publisher id" Format(uuid)
+// @Param created_from query string false "created at or after, RFC3339 or YYYY-MM-DD"
+// @Param created_to query string false "created at or before, RFC3339 or YYYY-MM-DD (whole day)"
+// @Param has_photo query bool false "has photo"
+// @Param include_deleted query bool false "include soft deleted news, editors only"
 // @Param page query int false "page number" Format(page)
 // @Param size query int false "number of elements per page" Format(size)
+// @Param orderBy query string false "comma separated sort fields, '-' prefix for descending: -created_at,title"
+// @Param cursor query string false "opaque cursor from next_cursor, presence switches to cursor pagination, empty for first page"
 // @Success 200 {object} models.NewsList
+// @Failure 400 {object} httpErrors.RestErr
 // @Failure 500 {object} httpErrors.RestErr
 // @Router /news/list [get]
 func (h *newsHandlers) GetAll() echo.HandlerFunc {
@@ -175,7 +268,13 @@ func (h *newsHandlers) GetAll() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		newsList, err := h.newsUC.GetAll(c.Request().Context(), c.QueryParam("title"), pq)
+		filter, err := utils.GetListFilterFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		newsList, err := h.newsUC.GetAll(c.Request().Context(), filter, pq)
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
@@ -193,6 +292,8 @@ func (h *newsHandlers) GetAll() echo.HandlerFunc {
 // @Produce  json
 // @Param id path string true "id"
 // @Success 200 {string} string	"ok"
+// @Failure 401 {object} httpErrors.RestErr
+// @Failure 403 {object} httpErrors.RestErr
 // @Failure 500 {object} httpErrors.RestErr
 // @Router /news/soft/{id} [delete]
 func (h *newsHandlers) SoftDelete() echo.HandlerFunc {
@@ -212,3 +313,141 @@ func (h *newsHandlers) SoftDelete() echo.HandlerFunc {
 		return c.NoContent(http.StatusOK)
 	}
 }
+
+// Restore
+// @Summary Restore news
+// @Description restore soft deleted news
+// @Tags News
+// @Accept  json
+// @Produce  json
+// @Param id path string true "id"
+// @Success 200 {object} models.News
+// @Failure 401 {object} httpErrors.RestErr
+// @Failure 403 {object} httpErrors.RestErr
+// @Failure 404 {object} httpErrors.RestErr
+// @Failure 500 {object} httpErrors.RestErr
+// @Router /news/{id}/restore [post]
+func (h *newsHandlers) Restore() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		newsID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		restoredNews, err := h.newsUC.Restore(c.Request().Context(), newsID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, restoredNews)
+	}
+}
+
+// GetTrash
+// @Summary Get News trash
+// @Description Get soft deleted news, recently deleted first. Authors see only their own news
+// @Tags News
+// @Accept  json
+// @Produce  json
+// @Param title query string false "title contains, case insensitive"
+// @Param published_by query string false "publisher id" Format(uuid)
+// @Param created_from query string false "created at or after, RFC3339 or YYYY-MM-DD"
+// @Param created_to query string false "created at or before, RFC3339 or YYYY-MM-DD (whole day)"
+// @Param has_photo query bool false "has photo"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Success 200 {object} models.NewsList
+// @Failure 400 {object} httpErrors.RestErr
+// @Failure 401 {object} httpErrors.RestErr
+// @Failure 403 {object} httpErrors.RestErr
+// @Failure 500 {object} httpErrors.RestErr
+// @Router /news/trash [get]
+func (h *newsHandlers) GetTrash() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		pq, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		filter, err := utils.GetListFilterFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		newsList, err := h.newsUC.GetTrash(c.Request().Context(), filter, pq)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, newsList)
+	}
+}
+
+// Purge
+// @Summary Purge News trash
+// @Description hard delete news which stayed in trash longer than retention, admins only
+// @Tags News
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} models.PurgeResult
+// @Failure 401 {object} httpErrors.RestErr
+// @Failure 403 {object} httpErrors.RestErr
+// @Failure 409 {object} httpErrors.RestErr
+// @Failure 500 {object} httpErrors.RestErr
+// @Router /news/trash [delete]
+func (h *newsHandlers) Purge() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		purged, err := h.newsUC.Purge(c.Request().Context())
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, &models.PurgeResult{Purged: purged})
+	}
+}
+
+// Search
+// @Summary Search News
+// @Description Full text search by title and description, results are ranked by relevance
+// @Tags News
+// @Accept  json
+// @Produce  json
+// @Param q query string true "search text"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Success 200 {object} models.NewsList
+// @Failure 400 {object} httpErrors.RestErr
+// @Failure 500 {object} httpErrors.RestErr
+// @Router /news/search [get]
+func (h *newsHandlers) Search() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		text := strings.TrimSpace(c.QueryParam("q"))
+		if text == "" {
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewBadRequestError(httpErrors.BadQueryParams))
+		}
+
+		pq, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		newsList, err := h.newsUC.Search(c.Request().Context(), text, pq)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, newsList)
+	}
+}
@@ -0,0 +1,54 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/internal/middleware"
+	"github.com/AliIsmoilov/golang_monolight/pkg/logger"
+)
+
+func TestNewsHandlers_ErrorLogRequestID(t *testing.T) {
+	t.Parallel()
+
+	level := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	core, logs := observer.New(level)
+	apiLogger := logger.NewCoreLogger(core, level)
+
+	cfg := &config.Config{}
+	mw := middleware.NewMiddlewareManager(nil, cfg, nil, nil, apiLogger)
+	newsHandlers := NewNewsHandlers(cfg, nil, apiLogger)
+
+	e := echo.New()
+	e.Use(echoMiddleware.RequestID())
+	e.Use(mw.AccessLog)
+	e.GET("/news/:id", newsHandlers.GetByID())
+
+	req := httptest.NewRequest(http.MethodGet, "/news/not-uuid", nil)
+	req.Header.Set(echo.HeaderXRequestID, "req-1")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.NotEqual(t, http.StatusOK, rec.Code)
+
+	errorLogs := logs.FilterMessage("Response error").AllUntimed()
+	require.Len(t, errorLogs, 1)
+	require.Equal(t, "req-1", errorLogs[0].ContextMap()["request_id"])
+
+	// request_id comes only from context logger, not duplicated by request fields
+	keys := 0
+	for _, field := range errorLogs[0].Context {
+		if field.Key == "request_id" {
+			keys++
+		}
+	}
+	require.Equal(t, 1, keys)
+}
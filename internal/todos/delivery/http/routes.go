@@ -3,27 +3,39 @@ package http
 import (
 	"github.com/labstack/echo/v4"
 
+	"github.com/AliIsmoilov/golang_monolight/internal/middleware"
 	"github.com/AliIsmoilov/golang_monolight/internal/todos"
 )
 
 // Map todos routes
-func MapToDosRoutes(todoGroup *echo.Group, h todos.Handlers) {
+func MapToDosRoutes(todoGroup *echo.Group, h todos.Handlers, mw *middleware.MiddlewareManager) {
 	// docs.SwaggerInfo.Title = cfg.ServiceName
 	// docs.SwaggerInfo.Version = cfg.Version
 	// docs.SwaggerInfo.Schemes = []string{cfg.HTTPScheme}
-	todoGroup.POST("", h.Create())
-	todoGroup.DELETE("/:id", h.Delete())
-	todoGroup.PUT("/:id", h.Update())
-	todoGroup.GET("/list", h.GetAll())
-	todoGroup.GET("/:id", h.GetByID())
+	todoGroup.POST("", h.Create(), mw.AuthJWTMiddleware, mw.CSRF)
+	todoGroup.DELETE("/:id", h.Delete(), mw.AuthJWTMiddleware, mw.CSRF)
+	todoGroup.DELETE("/soft/:id", h.SoftDelete(), mw.AuthJWTMiddleware, mw.CSRF)
+	todoGroup.POST("/:id/restore", h.Restore(), mw.AuthJWTMiddleware, mw.CSRF)
+	todoGroup.DELETE("/trash", h.Purge(), mw.AuthJWTMiddleware, mw.CSRF)
+	todoGroup.PUT("/:id", h.Update(), mw.AuthJWTMiddleware, mw.CSRF)
+	todoGroup.PATCH("/:id", h.Patch(), mw.AuthJWTMiddleware, mw.CSRF)
+	todoGroup.GET("/list", h.GetAll(), mw.OptionalAuthJWTMiddleware)
+	todoGroup.GET("/trash", h.GetTrash(), mw.AuthJWTMiddleware)
+	todoGroup.GET("/search", h.Search())
+	todoGroup.GET("/:id", h.GetByID(), mw.OptionalAuthJWTMiddleware)
 }
 
 // Map news routes
-func MapNewsRoutes(newsGroup *echo.Group, h todos.NewsHandlers) {
-	newsGroup.POST("", h.Create())
-	newsGroup.DELETE("/:id", h.Delete())
-	newsGroup.DELETE("/soft/:id", h.SoftDelete())
-	newsGroup.PUT("/:id", h.Update())
-	newsGroup.GET("/list", h.GetAll())
-	newsGroup.GET("/:id", h.GetByID())
+func MapNewsRoutes(newsGroup *echo.Group, h todos.NewsHandlers, mw *middleware.MiddlewareManager) {
+	newsGroup.POST("", h.Create(), mw.AuthJWTMiddleware, mw.CSRF)
+	newsGroup.DELETE("/:id", h.Delete(), mw.AuthJWTMiddleware, mw.CSRF)
+	newsGroup.DELETE("/soft/:id", h.SoftDelete(), mw.AuthJWTMiddleware, mw.CSRF)
+	newsGroup.POST("/:id/restore", h.Restore(), mw.AuthJWTMiddleware, mw.CSRF)
+	newsGroup.DELETE("/trash", h.Purge(), mw.AuthJWTMiddleware, mw.CSRF)
+	newsGroup.PUT("/:id", h.Update(), mw.AuthJWTMiddleware, mw.CSRF)
+	newsGroup.PATCH("/:id", h.Patch(), mw.AuthJWTMiddleware, mw.CSRF)
+	newsGroup.GET("/list", h.GetAll(), mw.OptionalAuthJWTMiddleware)
+	newsGroup.GET("/trash", h.GetTrash(), mw.AuthJWTMiddleware)
+	newsGroup.GET("/search", h.Search())
+	newsGroup.GET("/:id", h.GetByID(), mw.OptionalAuthJWTMiddleware)
 }
@@ -3,6 +3,7 @@ package todos
 
 import (
 	"context"
+	"time"
 
 	"github.com/AliIsmoilov/golang_monolight/internal/models"
 	"github.com/AliIsmoilov/golang_monolight/pkg/utils"
@@ -13,9 +14,15 @@ import (
 type BlogRepository interface {
 	Create(ctx context.Context, blog *models.Blog) (*models.Blog, error)
 	Update(ctx context.Context, todo *models.Blog) (*models.Blog, error)
+	Patch(ctx context.Context, blogID uuid.UUID, patch *models.BlogPatch, version int) (*models.Blog, error)
 	Delete(ctx context.Context, todoID uuid.UUID) error
-	GetByID(ctx context.Context, blogID uuid.UUID) (*models.Blog, error)
-	GetAll(ctx context.Context, title string, query *utils.PaginationQuery) (*models.BlogsList, error)
+	SoftDelete(ctx context.Context, blogID uuid.UUID) error
+	Restore(ctx context.Context, blogID uuid.UUID) (*models.Blog, error)
+	GetByID(ctx context.Context, blogID uuid.UUID, includeDeleted bool) (*models.Blog, error)
+	GetAll(ctx context.Context, filter *utils.ListFilter, query *utils.PaginationQuery) (*models.BlogsList, error)
+	GetTrash(ctx context.Context, filter *utils.ListFilter, query *utils.PaginationQuery) (*models.BlogsList, error)
+	Search(ctx context.Context, text string, query *utils.PaginationQuery) (*models.BlogsList, error)
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
 
 	// CreateNews(ctx context.Context, new *models.News) (*models.News, error)
 }
@@ -24,8 +31,13 @@ type BlogRepository interface {
 type NewsRepository interface {
 	Create(ctx context.Context, new *models.News) (*models.News, error)
 	Update(ctx context.Context, new *models.News) (*models.News, error)
+	Patch(ctx context.Context, newID uuid.UUID, patch *models.NewsPatch, version int) (*models.News, error)
 	Delete(ctx context.Context, newID uuid.UUID) error
 	SoftDelete(ctx context.Context, newID uuid.UUID) error
-	GetByID(ctx context.Context, newID uuid.UUID) (*models.News, error)
-	GetAll(ctx context.Context, title string, query *utils.PaginationQuery) (*models.NewsList, error)
+	Restore(ctx context.Context, newID uuid.UUID) (*models.News, error)
+	GetByID(ctx context.Context, newID uuid.UUID, includeDeleted bool) (*models.News, error)
+	GetAll(ctx context.Context, filter *utils.ListFilter, query *utils.PaginationQuery) (*models.NewsList, error)
+	GetTrash(ctx context.Context, filter *utils.ListFilter, query *utils.PaginationQuery) (*models.NewsList, error)
+	Search(ctx context.Context, text string, query *utils.PaginationQuery) (*models.NewsList, error)
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
 }
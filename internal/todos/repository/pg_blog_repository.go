@@ -3,7 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
-	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -11,29 +11,43 @@ import (
 
 	"github.com/AliIsmoilov/golang_monolight/internal/models"
 	"github.com/AliIsmoilov/golang_monolight/internal/todos"
+	"github.com/AliIsmoilov/golang_monolight/pkg/query"
+	"github.com/AliIsmoilov/golang_monolight/pkg/tracing"
 	"github.com/AliIsmoilov/golang_monolight/pkg/utils"
 )
 
+// Sortable blogs fields mapped to columns
+var blogsSortColumns = map[string]string{
+	"id":           "id",
+	"title":        "title",
+	"created_at":   "created_at",
+	"published_by": "published_by",
+}
+
 // Blog Repository
 type blogsRepo struct {
-	db *sqlx.DB
+	db *tracing.DB
 }
 
 // ToDos Repository constructor
 func NewToDosRepository(db *sqlx.DB) todos.BlogRepository {
-	return &blogsRepo{db: db}
+	return &blogsRepo{db: tracing.WrapDB(db)}
 }
 
 // Create todo
 func (r *blogsRepo) Create(ctx context.Context, todo *models.Blog) (*models.Blog, error) {
+	ctx, span := tracing.Start(ctx, "blogsRepo.Create")
+	defer span.End()
+
 	newUUID := uuid.New()
 	c := &models.Blog{}
-	createBlog := `INSERT INTO blogs (id, title) VALUES ($1, $2) RETURNING *`
+	createBlog := `INSERT INTO blogs (id, title, published_by) VALUES ($1, $2, $3) RETURNING id, title, published_by, created_at, updated_at, version`
 	if err := r.db.QueryRowxContext(
 		ctx,
 		createBlog,
 		newUUID,
 		&todo.Title,
+		&todo.PublishedBy,
 	).StructScan(c); err != nil {
 		return nil, errors.Wrap(err, "blogRepo.Create.StructScan")
 	}
@@ -43,17 +57,49 @@ func (r *blogsRepo) Create(ctx context.Context, todo *models.Blog) (*models.Blog
 
 // Update blog
 func (r *blogsRepo) Update(ctx context.Context, blog *models.Blog) (*models.Blog, error) {
-	updateBlog := `UPDATE blogs SET title = $1 WHERE id = $2 RETURNING *`
+	ctx, span := tracing.Start(ctx, "blogsRepo.Update")
+	defer span.End()
+
+	updateBlog := `
+		UPDATE blogs SET title = $1, version = version + 1, updated_at = now()
+		WHERE id = $2 AND deleted_at IS NULL AND ($3 = 0 OR version = $3)
+		RETURNING id, title, published_by, created_at, updated_at, version`
 	res := &models.Blog{}
-	if err := r.db.QueryRowxContext(ctx, updateBlog, blog.Title, blog.ID).StructScan(res); err != nil {
+	if err := r.db.QueryRowxContext(ctx, updateBlog, blog.Title, blog.ID, blog.Version).StructScan(res); err != nil {
 		return nil, errors.Wrap(err, "blogsRepo.Update.QueryRowxContext")
 	}
 
 	return res, nil
 }
 
+// Patch blog, only present fields are updated, zero version skips version check
+func (r *blogsRepo) Patch(ctx context.Context, blogID uuid.UUID, patch *models.BlogPatch, version int) (*models.Blog, error) {
+	ctx, span := tracing.Start(ctx, "blogsRepo.Patch")
+	defer span.End()
+
+	update := query.New().
+		SetIf(patch.Title.Set, "title = ?", patch.Title.Value).
+		Set("version = version + 1").
+		Set("updated_at = now()").
+		Where("id = ?", blogID).
+		Where("deleted_at IS NULL").
+		Where("(? = 0 OR version = ?)", version, version)
+
+	patchBlog := `UPDATE blogs` + update.SetClause() + update.WhereClause() + `
+		RETURNING id, title, published_by, created_at, updated_at, version`
+	res := &models.Blog{}
+	if err := r.db.QueryRowxContext(ctx, patchBlog, update.Args()...).StructScan(res); err != nil {
+		return nil, errors.Wrap(err, "blogsRepo.Patch.QueryRowxContext")
+	}
+
+	return res, nil
+}
+
 // Delete blog
 func (r *blogsRepo) Delete(ctx context.Context, blogID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "blogsRepo.Delete")
+	defer span.End()
+
 	deleteBlog := `DELETE FROM blogs WHERE id = $1`
 
 	result, err := r.db.ExecContext(ctx, deleteBlog, blogID)
@@ -72,32 +118,40 @@ func (r *blogsRepo) Delete(ctx context.Context, blogID uuid.UUID) error {
 	return nil
 }
 
-// GetByID blog
-func (r *blogsRepo) GetByID(ctx context.Context, blogId uuid.UUID) (*models.Blog, error) {
-	getBlogByID := `SELECT id, title, created_at
+// GetByID blog, soft deleted blogs are not found unless includeDeleted is set
+func (r *blogsRepo) GetByID(ctx context.Context, blogId uuid.UUID, includeDeleted bool) (*models.Blog, error) {
+	ctx, span := tracing.Start(ctx, "blogsRepo.GetByID")
+	defer span.End()
+
+	getBlogByID := `SELECT id, title, published_by, created_at, updated_at, version, deleted_at
 	FROM blogs
-	WHERE id = $1`
+	WHERE id = $1 AND ($2 OR deleted_at IS NULL)`
 	blog := &models.Blog{}
-	if err := r.db.GetContext(ctx, blog, getBlogByID, blogId); err != nil {
+	if err := r.db.GetContext(ctx, blog, getBlogByID, blogId, includeDeleted); err != nil {
 		return nil, errors.Wrap(err, "blogsRepo.GetByID.GetContext")
 	}
 	return blog, nil
 }
 
 // GetAll ToDos
-func (r *blogsRepo) GetAll(ctx context.Context, title string, query *utils.PaginationQuery) (*models.BlogsList, error) {
-	var (
-		totalCount    int
-		getTotalCount = `SELECT COUNT(id) FROM blogs WHERE 1=1`
-		getAllToDos   = `SELECT id, title, created_at
-							FROM blogs where 1=1`
-	)
-	if title != "" {
-		getTotalCount = fmt.Sprintf("%s%s", getTotalCount, " and title LIKE '%"+title+"%';")
-		getAllToDos = fmt.Sprintf("%s%s", getAllToDos, " and title LIKE '%"+title+"%' ")
+func (r *blogsRepo) GetAll(ctx context.Context, filter *utils.ListFilter, query *utils.PaginationQuery) (*models.BlogsList, error) {
+	ctx, span := tracing.Start(ctx, "blogsRepo.GetAll")
+	defer span.End()
+
+	if query.IsCursorMode() {
+		return r.getAllByCursor(ctx, filter, query)
 	}
-	getAllToDos += " ORDER BY created_at OFFSET $1 LIMIT $2;"
-	if err := r.db.QueryRowContext(ctx, getTotalCount).Scan(&totalCount); err != nil {
+
+	var totalCount int
+
+	orderBy, err := query.GetOrderByClause(blogsSortColumns, utils.SortField{Field: "created_at"})
+	if err != nil {
+		return nil, err
+	}
+
+	where := blogsListConditions(filter)
+	getTotalCount := `SELECT COUNT(id) FROM blogs` + where.WhereClause()
+	if err := r.db.QueryRowContext(ctx, getTotalCount, where.Args()...).Scan(&totalCount); err != nil {
 		return nil, errors.Wrap(err, "blogsRepo.GetAll.QueryRowContext")
 	}
 
@@ -112,7 +166,12 @@ func (r *blogsRepo) GetAll(ctx context.Context, title string, query *utils.Pagin
 		}, nil
 	}
 
-	rows, err := r.db.QueryxContext(ctx, getAllToDos, query.GetOffset(), query.GetLimit())
+	page := where.Clone()
+	getAllToDos := `SELECT id, title, published_by, created_at, updated_at, version, deleted_at
+						FROM blogs` + page.WhereClause() +
+		` ORDER BY ` + orderBy + ` OFFSET ` + page.Arg(query.GetOffset()) + ` LIMIT ` + page.Arg(query.GetLimit())
+
+	rows, err := r.db.QueryxContext(ctx, getAllToDos, page.Args()...)
 	if err != nil {
 		return nil, errors.Wrap(err, "blogsRepo.GetAll.QueryxContext")
 	}
@@ -140,3 +199,220 @@ func (r *blogsRepo) GetAll(ctx context.Context, title string, query *utils.Pagin
 		Blogs:      blogsList,
 	}, nil
 }
+
+// Keyset pagination by (created_at, id), no total count is calculated
+func (r *blogsRepo) getAllByCursor(ctx context.Context, filter *utils.ListFilter, query *utils.PaginationQuery) (*models.BlogsList, error) {
+	desc, err := query.GetKeysetDesc("created_at")
+	if err != nil {
+		return nil, err
+	}
+
+	where := blogsListConditions(filter)
+	orderBy := "created_at ASC, id ASC"
+	if cursor := query.GetCursor(); cursor != nil && desc {
+		where.Where("(created_at, id) < (?, ?)", cursor.CreatedAt, cursor.ID)
+	} else if cursor != nil {
+		where.Where("(created_at, id) > (?, ?)", cursor.CreatedAt, cursor.ID)
+	}
+	if desc {
+		orderBy = "created_at DESC, id DESC"
+	}
+
+	// One extra row tells if there is next page
+	getAllBlogs := `SELECT id, title, published_by, created_at, updated_at, version, deleted_at
+						FROM blogs` + where.WhereClause() +
+		` ORDER BY ` + orderBy + ` LIMIT ` + where.Arg(query.GetLimit()+1)
+
+	blogList := make([]*models.Blog, 0, query.GetSize()+1)
+	if err = r.db.SelectContext(ctx, &blogList, getAllBlogs, where.Args()...); err != nil {
+		return nil, errors.Wrap(err, "blogsRepo.getAllByCursor.SelectContext")
+	}
+
+	hasMore := len(blogList) > query.GetLimit()
+	nextCursor := ""
+	if hasMore {
+		blogList = blogList[:query.GetLimit()]
+		last := blogList[len(blogList)-1]
+		nextCursor = utils.EncodeCursor(last.CreatedAt, last.ID)
+	}
+
+	return &models.BlogsList{
+		Size:       query.GetSize(),
+		HasMore:    hasMore,
+		Cursor:     query.Cursor,
+		NextCursor: nextCursor,
+		Blogs:      blogList,
+	}, nil
+}
+
+// Soft delete blog, it stays in trash until restored or purged
+func (r *blogsRepo) SoftDelete(ctx context.Context, blogID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "blogsRepo.SoftDelete")
+	defer span.End()
+
+	softDeleteBlog := `UPDATE blogs SET deleted_at = now(), version = version + 1, updated_at = now() WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, softDeleteBlog, blogID)
+	if err != nil {
+		return errors.Wrap(err, "blogsRepo.SoftDelete.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "blogsRepo.SoftDelete.RowsAffected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "blogsRepo.SoftDelete.rowsAffected")
+	}
+
+	return nil
+}
+
+// Restore soft deleted blog
+func (r *blogsRepo) Restore(ctx context.Context, blogID uuid.UUID) (*models.Blog, error) {
+	ctx, span := tracing.Start(ctx, "blogsRepo.Restore")
+	defer span.End()
+
+	restoreBlog := `UPDATE blogs SET deleted_at = NULL, version = version + 1, updated_at = now() WHERE id = $1 AND deleted_at IS NOT NULL RETURNING id, title, published_by, created_at, updated_at, version, deleted_at`
+	res := &models.Blog{}
+	if err := r.db.QueryRowxContext(ctx, restoreBlog, blogID).StructScan(res); err != nil {
+		return nil, errors.Wrap(err, "blogsRepo.Restore.QueryRowxContext")
+	}
+
+	return res, nil
+}
+
+// Get soft deleted blogs, recently deleted first
+func (r *blogsRepo) GetTrash(ctx context.Context, filter *utils.ListFilter, query *utils.PaginationQuery) (*models.BlogsList, error) {
+	ctx, span := tracing.Start(ctx, "blogsRepo.GetTrash")
+	defer span.End()
+
+	var totalCount int
+
+	trashFilter := *filter
+	trashFilter.IncludeDeleted = true
+	where := blogsListConditions(&trashFilter).Where("deleted_at IS NOT NULL")
+
+	getTotalCount := `SELECT COUNT(id) FROM blogs` + where.WhereClause()
+	if err := r.db.QueryRowContext(ctx, getTotalCount, where.Args()...).Scan(&totalCount); err != nil {
+		return nil, errors.Wrap(err, "blogsRepo.GetTrash.QueryRowContext")
+	}
+
+	if totalCount == 0 {
+		return &models.BlogsList{
+			TotalCount: totalCount,
+			TotalPages: utils.GetTotalPages(totalCount, query.GetSize()),
+			Page:       query.GetPage(),
+			Size:       query.GetSize(),
+			HasMore:    utils.GetHasMore(query.GetPage(), totalCount, query.GetSize()),
+			Blogs:      make([]*models.Blog, 0),
+		}, nil
+	}
+
+	page := where.Clone()
+	getTrash := `SELECT id, title, published_by, created_at, updated_at, version, deleted_at
+						FROM blogs` + page.WhereClause() +
+		` ORDER BY deleted_at DESC, id ASC OFFSET ` + page.Arg(query.GetOffset()) + ` LIMIT ` + page.Arg(query.GetLimit())
+
+	blogsList := make([]*models.Blog, 0, query.GetSize())
+	if err := r.db.SelectContext(ctx, &blogsList, getTrash, page.Args()...); err != nil {
+		return nil, errors.Wrap(err, "blogsRepo.GetTrash.SelectContext")
+	}
+
+	return &models.BlogsList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, query.GetSize()),
+		Page:       query.GetPage(),
+		Size:       query.GetSize(),
+		HasMore:    utils.GetHasMore(query.GetPage(), totalCount, query.GetSize()),
+		Blogs:      blogsList,
+	}, nil
+}
+
+// Hard delete blogs soft deleted before given time, returns number of purged blogs
+func (r *blogsRepo) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	ctx, span := tracing.Start(ctx, "blogsRepo.Purge")
+	defer span.End()
+
+	purgeBlogs := `DELETE FROM blogs WHERE deleted_at IS NOT NULL AND deleted_at < $1`
+
+	result, err := r.db.ExecContext(ctx, purgeBlogs, deletedBefore)
+	if err != nil {
+		return 0, errors.Wrap(err, "blogsRepo.Purge.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "blogsRepo.Purge.RowsAffected")
+	}
+
+	return rowsAffected, nil
+}
+
+// Blogs list conditions, blogs have no photo
+func blogsListConditions(filter *utils.ListFilter) *query.Builder {
+	where := query.New().
+		WhereIf(!filter.IncludeDeleted, "deleted_at IS NULL").
+		WhereIf(filter.Title != "", "title ILIKE ?", "%"+query.EscapeLike(filter.Title)+"%")
+
+	if filter.PublishedBy != nil {
+		where.Where("published_by = ?", *filter.PublishedBy)
+	}
+	if filter.CreatedFrom != nil {
+		where.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		where.Where("created_at <= ?", *filter.CreatedTo)
+	}
+
+	return where
+}
+
+// Search blogs by title, results are ranked by relevance
+func (r *blogsRepo) Search(ctx context.Context, text string, query *utils.PaginationQuery) (*models.BlogsList, error) {
+	ctx, span := tracing.Start(ctx, "blogsRepo.Search")
+	defer span.End()
+
+	var (
+		totalCount    int
+		getTotalCount = `
+			SELECT COUNT(id)
+			FROM blogs
+			WHERE deleted_at IS NULL AND search_vector @@ websearch_to_tsquery('simple', $1)`
+		searchBlogs = `
+			SELECT id, title, published_by, created_at, updated_at, version,
+				ts_rank(search_vector, q) AS rank,
+				ts_headline('simple', title, q, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') AS headline
+			FROM blogs, websearch_to_tsquery('simple', $1) q
+			WHERE deleted_at IS NULL AND search_vector @@ q
+			ORDER BY rank DESC, created_at DESC
+			OFFSET $2 LIMIT $3`
+	)
+	if err := r.db.QueryRowContext(ctx, getTotalCount, text).Scan(&totalCount); err != nil {
+		return nil, errors.Wrap(err, "blogsRepo.Search.QueryRowContext")
+	}
+
+	if totalCount == 0 {
+		return &models.BlogsList{
+			TotalCount: totalCount,
+			TotalPages: utils.GetTotalPages(totalCount, query.GetSize()),
+			Page:       query.GetPage(),
+			Size:       query.GetSize(),
+			HasMore:    utils.GetHasMore(query.GetPage(), totalCount, query.GetSize()),
+			Blogs:      make([]*models.Blog, 0),
+		}, nil
+	}
+
+	blogsList := make([]*models.Blog, 0, query.GetSize())
+	if err := r.db.SelectContext(ctx, &blogsList, searchBlogs, text, query.GetOffset(), query.GetLimit()); err != nil {
+		return nil, errors.Wrap(err, "blogsRepo.Search.SelectContext")
+	}
+
+	return &models.BlogsList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, query.GetSize()),
+		Page:       query.GetPage(),
+		Size:       query.GetSize(),
+		HasMore:    utils.GetHasMore(query.GetPage(), totalCount, query.GetSize()),
+		Blogs:      blogsList,
+	}, nil
+}
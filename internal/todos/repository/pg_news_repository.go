@@ -3,28 +3,41 @@ package repository
 import (
 	"context"
 	"database/sql"
-	"fmt"
+	"time"
 
 	"github.com/AliIsmoilov/golang_monolight/internal/models"
 	"github.com/AliIsmoilov/golang_monolight/internal/todos"
+	"github.com/AliIsmoilov/golang_monolight/pkg/query"
+	"github.com/AliIsmoilov/golang_monolight/pkg/tracing"
 	"github.com/AliIsmoilov/golang_monolight/pkg/utils"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 )
 
+// Sortable news fields mapped to columns
+var newsSortColumns = map[string]string{
+	"id":           "id",
+	"title":        "title",
+	"created_at":   "created_at",
+	"published_by": "published_by",
+}
+
 // News Repository
 type newsRepo struct {
-	db *sqlx.DB
+	db *tracing.DB
 }
 
 // ToDos Repository constructor
 func NewNewsRepository(db *sqlx.DB) todos.NewsRepository {
-	return &newsRepo{db: db}
+	return &newsRepo{db: tracing.WrapDB(db)}
 }
 
 // Create News
 func (r *newsRepo) Create(ctx context.Context, new *models.News) (*models.News, error) {
+	ctx, span := tracing.Start(ctx, "newsRepo.Create")
+	defer span.End()
+
 	newUUID := uuid.New()
 	c := &models.News{}
 	createNews := `
@@ -33,7 +46,7 @@ func (r *newsRepo) Create(ctx context.Context, new *models.News) (*models.News,
 		VALUES 
 			($1, $2, $3, $4, $5) 
 		RETURNING 
-			id, title, description, photo, published_by, created_at`
+			id, title, description, photo, published_by, created_at, updated_at, version`
 	if err := r.db.QueryRowxContext(
 		ctx,
 		createNews,
@@ -51,18 +64,22 @@ func (r *newsRepo) Create(ctx context.Context, new *models.News) (*models.News,
 
 // Update news
 func (r *newsRepo) Update(ctx context.Context, new *models.News) (*models.News, error) {
+	ctx, span := tracing.Start(ctx, "newsRepo.Update")
+	defer span.End()
+
 	updateNews := `
 		UPDATE news 
 		SET 
 			title = $1,
 			description = $2,
 			photo = $3,
-			published_by = $4
-		WHERE id = $5 
-		RETURNING *`
+			version = version + 1,
+			updated_at = now()
+		WHERE id = $4 AND deleted_at IS NULL AND ($5 = 0 OR version = $5)
+		RETURNING id, title, description, photo, published_by, created_at, updated_at, version`
 	res := &models.News{}
 	if err := r.db.
-		QueryRowxContext(ctx, updateNews, new.Title, new.Description, new.Photo, new.PublishedBy, new.ID).
+		QueryRowxContext(ctx, updateNews, new.Title, new.Description, new.Photo, new.ID, new.Version).
 		StructScan(res); err != nil {
 		return nil, errors.Wrap(err, "newsRepo.Update.QueryRowxContext")
 	}
@@ -70,8 +87,36 @@ func (r *newsRepo) Update(ctx context.Context, new *models.News) (*models.News,
 	return res, nil
 }
 
+// Patch news, only present fields are updated, zero version skips version check
+func (r *newsRepo) Patch(ctx context.Context, newsID uuid.UUID, patch *models.NewsPatch, version int) (*models.News, error) {
+	ctx, span := tracing.Start(ctx, "newsRepo.Patch")
+	defer span.End()
+
+	update := query.New().
+		SetIf(patch.Title.Set, "title = ?", patch.Title.Value).
+		SetIf(patch.Description.Set, "description = ?", patch.Description.Value).
+		SetIf(patch.Photo.Set, "photo = ?", patch.Photo.Value).
+		Set("version = version + 1").
+		Set("updated_at = now()").
+		Where("id = ?", newsID).
+		Where("deleted_at IS NULL").
+		Where("(? = 0 OR version = ?)", version, version)
+
+	patchNews := `UPDATE news` + update.SetClause() + update.WhereClause() + `
+		RETURNING id, title, description, photo, published_by, created_at, updated_at, version`
+	res := &models.News{}
+	if err := r.db.QueryRowxContext(ctx, patchNews, update.Args()...).StructScan(res); err != nil {
+		return nil, errors.Wrap(err, "newsRepo.Patch.QueryRowxContext")
+	}
+
+	return res, nil
+}
+
 // Delete news
 func (r *newsRepo) Delete(ctx context.Context, newsID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "newsRepo.Delete")
+	defer span.End()
+
 	deleteNews := `DELETE FROM news WHERE id = $1`
 
 	result, err := r.db.ExecContext(ctx, deleteNews, newsID)
@@ -90,33 +135,41 @@ func (r *newsRepo) Delete(ctx context.Context, newsID uuid.UUID) error {
 	return nil
 }
 
-// GetByID news
-func (r *newsRepo) GetByID(ctx context.Context, newsId uuid.UUID) (*models.News, error) {
+// GetByID news, soft deleted news are not found unless includeDeleted is set
+func (r *newsRepo) GetByID(ctx context.Context, newsId uuid.UUID, includeDeleted bool) (*models.News, error) {
+	ctx, span := tracing.Start(ctx, "newsRepo.GetByID")
+	defer span.End()
+
 	getNewsByID := `
-		SELECT id, title, description, photo, published_by, created_at
+		SELECT id, title, description, photo, published_by, created_at, updated_at, version, deleted_at
 		FROM news
-		WHERE id = $1`
+		WHERE id = $1 AND ($2 OR deleted_at IS NULL)`
 	new := &models.News{}
-	if err := r.db.GetContext(ctx, new, getNewsByID, newsId); err != nil {
+	if err := r.db.GetContext(ctx, new, getNewsByID, newsId, includeDeleted); err != nil {
 		return nil, errors.Wrap(err, "newsRepo.GetByID.GetContext")
 	}
 	return new, nil
 }
 
 // GetAll news
-func (r *newsRepo) GetAll(ctx context.Context, title string, query *utils.PaginationQuery) (*models.NewsList, error) {
-	var (
-		totalCount    int
-		getTotalCount = `SELECT COUNT(id) FROM news WHERE 1=1 AND deleted_at IS NULL`
-		getAllNews    = `SELECT id, title, description, photo, published_by, created_at
-							FROM news where 1=1 AND deleted_at IS NULL`
-	)
-	if title != "" {
-		getTotalCount = fmt.Sprintf("%s%s", getTotalCount, " and title LIKE '%"+title+"%';")
-		getAllNews = fmt.Sprintf("%s%s", getAllNews, " and title LIKE '%"+title+"%' ")
+func (r *newsRepo) GetAll(ctx context.Context, filter *utils.ListFilter, query *utils.PaginationQuery) (*models.NewsList, error) {
+	ctx, span := tracing.Start(ctx, "newsRepo.GetAll")
+	defer span.End()
+
+	if query.IsCursorMode() {
+		return r.getAllByCursor(ctx, filter, query)
+	}
+
+	var totalCount int
+
+	orderBy, err := query.GetOrderByClause(newsSortColumns, utils.SortField{Field: "created_at"})
+	if err != nil {
+		return nil, err
 	}
-	getAllNews += " ORDER BY created_at OFFSET $1 LIMIT $2;"
-	if err := r.db.QueryRowContext(ctx, getTotalCount).Scan(&totalCount); err != nil {
+
+	where := newsListConditions(filter)
+	getTotalCount := `SELECT COUNT(id) FROM news` + where.WhereClause()
+	if err := r.db.QueryRowContext(ctx, getTotalCount, where.Args()...).Scan(&totalCount); err != nil {
 		return nil, errors.Wrap(err, "newsRepo.GetAll.QueryRowContext")
 	}
 
@@ -131,7 +184,12 @@ func (r *newsRepo) GetAll(ctx context.Context, title string, query *utils.Pagina
 		}, nil
 	}
 
-	rows, err := r.db.QueryxContext(ctx, getAllNews, query.GetOffset(), query.GetLimit())
+	page := where.Clone()
+	getAllNews := `SELECT id, title, description, photo, published_by, created_at, updated_at, version, deleted_at
+						FROM news` + page.WhereClause() +
+		` ORDER BY ` + orderBy + ` OFFSET ` + page.Arg(query.GetOffset()) + ` LIMIT ` + page.Arg(query.GetLimit())
+
+	rows, err := r.db.QueryxContext(ctx, getAllNews, page.Args()...)
 	if err != nil {
 		return nil, errors.Wrap(err, "newsRepo.GetAll.QueryxContext")
 	}
@@ -160,22 +218,231 @@ func (r *newsRepo) GetAll(ctx context.Context, title string, query *utils.Pagina
 	}, nil
 }
 
-// Update news
+// Keyset pagination by (created_at, id), no total count is calculated
+func (r *newsRepo) getAllByCursor(ctx context.Context, filter *utils.ListFilter, query *utils.PaginationQuery) (*models.NewsList, error) {
+	desc, err := query.GetKeysetDesc("created_at")
+	if err != nil {
+		return nil, err
+	}
+
+	where := newsListConditions(filter)
+	orderBy := "created_at ASC, id ASC"
+	if cursor := query.GetCursor(); cursor != nil && desc {
+		where.Where("(created_at, id) < (?, ?)", cursor.CreatedAt, cursor.ID)
+	} else if cursor != nil {
+		where.Where("(created_at, id) > (?, ?)", cursor.CreatedAt, cursor.ID)
+	}
+	if desc {
+		orderBy = "created_at DESC, id DESC"
+	}
+
+	// One extra row tells if there is next page
+	getAllNews := `SELECT id, title, description, photo, published_by, created_at, updated_at, version, deleted_at
+						FROM news` + where.WhereClause() +
+		` ORDER BY ` + orderBy + ` LIMIT ` + where.Arg(query.GetLimit()+1)
+
+	newsList := make([]*models.News, 0, query.GetSize()+1)
+	if err = r.db.SelectContext(ctx, &newsList, getAllNews, where.Args()...); err != nil {
+		return nil, errors.Wrap(err, "newsRepo.getAllByCursor.SelectContext")
+	}
+
+	hasMore := len(newsList) > query.GetLimit()
+	nextCursor := ""
+	if hasMore {
+		newsList = newsList[:query.GetLimit()]
+		last := newsList[len(newsList)-1]
+		nextCursor = utils.EncodeCursor(last.CreatedAt, last.ID)
+	}
+
+	return &models.NewsList{
+		Size:       query.GetSize(),
+		HasMore:    hasMore,
+		Cursor:     query.Cursor,
+		NextCursor: nextCursor,
+		News:       newsList,
+	}, nil
+}
+
+// News list conditions, news without photo keep nil uuid or NULL in photo column
+func newsListConditions(filter *utils.ListFilter) *query.Builder {
+	where := query.New().
+		WhereIf(!filter.IncludeDeleted, "deleted_at IS NULL").
+		WhereIf(filter.Title != "", "title ILIKE ?", "%"+query.EscapeLike(filter.Title)+"%")
+
+	if filter.PublishedBy != nil {
+		where.Where("published_by = ?", *filter.PublishedBy)
+	}
+	if filter.CreatedFrom != nil {
+		where.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		where.Where("created_at <= ?", *filter.CreatedTo)
+	}
+	if filter.HasPhoto != nil {
+		if *filter.HasPhoto {
+			where.Where("photo IS NOT NULL AND photo <> ?", uuid.Nil)
+		} else {
+			where.Where("(photo IS NULL OR photo = ?)", uuid.Nil)
+		}
+	}
+
+	return where
+}
+
+// Soft delete news, it stays in trash until restored or purged
 func (r *newsRepo) SoftDelete(ctx context.Context, newsID uuid.UUID) error {
-	softDeleteNews := `UPDATE news SET deleted_at = now() WHERE id = $1`
+	ctx, span := tracing.Start(ctx, "newsRepo.SoftDelete")
+	defer span.End()
+
+	softDeleteNews := `UPDATE news SET deleted_at = now(), version = version + 1, updated_at = now() WHERE id = $1 AND deleted_at IS NULL`
 
 	result, err := r.db.ExecContext(ctx, softDeleteNews, newsID)
 	if err != nil {
-		return errors.Wrap(err, "newsRepo.Delete.ExecContext")
+		return errors.Wrap(err, "newsRepo.SoftDelete.ExecContext")
 	}
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return errors.Wrap(err, "newsRepo.Delete.RowsAffected")
+		return errors.Wrap(err, "newsRepo.SoftDelete.RowsAffected")
 	}
 
 	if rowsAffected == 0 {
-		return errors.Wrap(sql.ErrNoRows, "newsRepo.Delete.rowsAffected")
+		return errors.Wrap(sql.ErrNoRows, "newsRepo.SoftDelete.rowsAffected")
 	}
 
 	return nil
 }
+
+// Restore soft deleted news
+func (r *newsRepo) Restore(ctx context.Context, newsID uuid.UUID) (*models.News, error) {
+	ctx, span := tracing.Start(ctx, "newsRepo.Restore")
+	defer span.End()
+
+	restoreNews := `
+		UPDATE news
+		SET deleted_at = NULL, version = version + 1, updated_at = now()
+		WHERE id = $1 AND deleted_at IS NOT NULL
+		RETURNING id, title, description, photo, published_by, created_at, updated_at, version, deleted_at`
+	res := &models.News{}
+	if err := r.db.QueryRowxContext(ctx, restoreNews, newsID).StructScan(res); err != nil {
+		return nil, errors.Wrap(err, "newsRepo.Restore.QueryRowxContext")
+	}
+
+	return res, nil
+}
+
+// Get soft deleted news, recently deleted first
+func (r *newsRepo) GetTrash(ctx context.Context, filter *utils.ListFilter, query *utils.PaginationQuery) (*models.NewsList, error) {
+	ctx, span := tracing.Start(ctx, "newsRepo.GetTrash")
+	defer span.End()
+
+	var totalCount int
+
+	trashFilter := *filter
+	trashFilter.IncludeDeleted = true
+	where := newsListConditions(&trashFilter).Where("deleted_at IS NOT NULL")
+
+	getTotalCount := `SELECT COUNT(id) FROM news` + where.WhereClause()
+	if err := r.db.QueryRowContext(ctx, getTotalCount, where.Args()...).Scan(&totalCount); err != nil {
+		return nil, errors.Wrap(err, "newsRepo.GetTrash.QueryRowContext")
+	}
+
+	if totalCount == 0 {
+		return &models.NewsList{
+			TotalCount: totalCount,
+			TotalPages: utils.GetTotalPages(totalCount, query.GetSize()),
+			Page:       query.GetPage(),
+			Size:       query.GetSize(),
+			HasMore:    utils.GetHasMore(query.GetPage(), totalCount, query.GetSize()),
+			News:       make([]*models.News, 0),
+		}, nil
+	}
+
+	page := where.Clone()
+	getTrash := `SELECT id, title, description, photo, published_by, created_at, updated_at, version, deleted_at
+						FROM news` + page.WhereClause() +
+		` ORDER BY deleted_at DESC, id ASC OFFSET ` + page.Arg(query.GetOffset()) + ` LIMIT ` + page.Arg(query.GetLimit())
+
+	newsList := make([]*models.News, 0, query.GetSize())
+	if err := r.db.SelectContext(ctx, &newsList, getTrash, page.Args()...); err != nil {
+		return nil, errors.Wrap(err, "newsRepo.GetTrash.SelectContext")
+	}
+
+	return &models.NewsList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, query.GetSize()),
+		Page:       query.GetPage(),
+		Size:       query.GetSize(),
+		HasMore:    utils.GetHasMore(query.GetPage(), totalCount, query.GetSize()),
+		News:       newsList,
+	}, nil
+}
+
+// Hard delete news soft deleted before given time, returns number of purged news
+func (r *newsRepo) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	ctx, span := tracing.Start(ctx, "newsRepo.Purge")
+	defer span.End()
+
+	purgeNews := `DELETE FROM news WHERE deleted_at IS NOT NULL AND deleted_at < $1`
+
+	result, err := r.db.ExecContext(ctx, purgeNews, deletedBefore)
+	if err != nil {
+		return 0, errors.Wrap(err, "newsRepo.Purge.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "newsRepo.Purge.RowsAffected")
+	}
+
+	return rowsAffected, nil
+}
+
+// Search news by title and description, results are ranked by relevance
+func (r *newsRepo) Search(ctx context.Context, text string, query *utils.PaginationQuery) (*models.NewsList, error) {
+	ctx, span := tracing.Start(ctx, "newsRepo.Search")
+	defer span.End()
+
+	var (
+		totalCount    int
+		getTotalCount = `
+			SELECT COUNT(id)
+			FROM news
+			WHERE deleted_at IS NULL AND search_vector @@ websearch_to_tsquery('simple', $1)`
+		searchNews = `
+			SELECT id, title, description, photo, published_by, created_at, updated_at, version,
+				ts_rank(search_vector, q) AS rank,
+				ts_headline('simple', title, q, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') AS headline,
+				ts_headline('simple', description, q, 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=30, MinWords=10') AS snippet
+			FROM news, websearch_to_tsquery('simple', $1) q
+			WHERE deleted_at IS NULL AND search_vector @@ q
+			ORDER BY rank DESC, created_at DESC
+			OFFSET $2 LIMIT $3`
+	)
+	if err := r.db.QueryRowContext(ctx, getTotalCount, text).Scan(&totalCount); err != nil {
+		return nil, errors.Wrap(err, "newsRepo.Search.QueryRowContext")
+	}
+
+	if totalCount == 0 {
+		return &models.NewsList{
+			TotalCount: totalCount,
+			TotalPages: utils.GetTotalPages(totalCount, query.GetSize()),
+			Page:       query.GetPage(),
+			Size:       query.GetSize(),
+			HasMore:    utils.GetHasMore(query.GetPage(), totalCount, query.GetSize()),
+			News:       make([]*models.News, 0),
+		}, nil
+	}
+
+	newsList := make([]*models.News, 0, query.GetSize())
+	if err := r.db.SelectContext(ctx, &newsList, searchNews, text, query.GetOffset(), query.GetLimit()); err != nil {
+		return nil, errors.Wrap(err, "newsRepo.Search.SelectContext")
+	}
+
+	return &models.NewsList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, query.GetSize()),
+		Page:       query.GetPage(),
+		Size:       query.GetSize(),
+		HasMore:    utils.GetHasMore(query.GetPage(), totalCount, query.GetSize()),
+		News:       newsList,
+	}, nil
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+	"github.com/AliIsmoilov/golang_monolight/pkg/utils"
+)
+
+func TestNewsRepo_Restore(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	newsRepo := NewNewsRepository(sqlxDB)
+
+	t.Run("Restore", func(t *testing.T) {
+		newsID := uuid.New()
+		rows := sqlmock.NewRows([]string{"id", "title", "description", "photo", "published_by", "created_at", "deleted_at"}).
+			AddRow(newsID, "title", "description", uuid.Nil, uuid.New(), time.Now(), nil)
+
+		mock.ExpectQuery(`UPDATE news\s+SET deleted_at = NULL, version = version \+ 1, updated_at = now\(\)\s+WHERE id = \$1 AND deleted_at IS NOT NULL`).WithArgs(newsID).WillReturnRows(rows)
+
+		restoredNews, err := newsRepo.Restore(context.Background(), newsID)
+		require.NoError(t, err)
+		require.Equal(t, newsID, restoredNews.ID)
+		require.Nil(t, restoredNews.DeletedAt)
+	})
+
+	t.Run("Restore not deleted", func(t *testing.T) {
+		newsID := uuid.New()
+
+		mock.ExpectQuery(`UPDATE news\s+SET deleted_at = NULL`).WithArgs(newsID).WillReturnError(sql.ErrNoRows)
+
+		_, err := newsRepo.Restore(context.Background(), newsID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNewsRepo_Purge(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	newsRepo := NewNewsRepository(sqlxDB)
+	deletedBefore := time.Now().Add(-time.Hour)
+
+	mock.ExpectExec(`DELETE FROM news WHERE deleted_at IS NOT NULL AND deleted_at < \$1`).WithArgs(deletedBefore).WillReturnResult(sqlmock.NewResult(0, 3))
+
+	purged, err := newsRepo.Purge(context.Background(), deletedBefore)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), purged)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNewsRepo_Patch(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	newsRepo := NewNewsRepository(sqlxDB)
+
+	t.Run("Present fields only", func(t *testing.T) {
+		newsID := uuid.New()
+		patch := &models.NewsPatch{}
+		require.NoError(t, json.Unmarshal([]byte(`{"title": "new title", "photo": null}`), patch))
+
+		rows := sqlmock.NewRows([]string{"id", "title", "description", "photo", "published_by", "created_at", "version"}).
+			AddRow(newsID, "new title", "description", uuid.Nil, uuid.New(), time.Now(), 3)
+		mock.ExpectQuery(`UPDATE news SET title = \$1, photo = \$2, version = version \+ 1, updated_at = now\(\) `+
+			`WHERE id = \$3 AND deleted_at IS NULL AND \(\$4 = 0 OR version = \$5\)`).
+			WithArgs("new title", uuid.Nil, newsID, 2, 2).
+			WillReturnRows(rows)
+
+		patchedNews, err := newsRepo.Patch(context.Background(), newsID, patch, 2)
+		require.NoError(t, err)
+		require.Equal(t, "new title", patchedNews.Title)
+		require.Equal(t, 3, patchedNews.Version)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNewsRepo_Search(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	newsRepo := NewNewsRepository(sqlxDB)
+	countQuery := `SELECT COUNT\(id\)\s+FROM news\s+WHERE deleted_at IS NULL AND search_vector @@ websearch_to_tsquery\('simple', \$1\)`
+	searchQuery := `SELECT id, title, description, photo, published_by, created_at, updated_at, version,\s+` +
+		`ts_rank\(search_vector, q\) AS rank,\s+` +
+		`ts_headline\('simple', title, q, .+\) AS headline,\s+` +
+		`ts_headline\('simple', description, q, .+\) AS snippet\s+` +
+		`FROM news, websearch_to_tsquery\('simple', \$1\) q\s+` +
+		`WHERE deleted_at IS NULL AND search_vector @@ q\s+` +
+		`ORDER BY rank DESC, created_at DESC\s+OFFSET \$2 LIMIT \$3`
+
+	t.Run("Search", func(t *testing.T) {
+		mock.ExpectQuery(countQuery).WithArgs("go -java").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		rows := sqlmock.NewRows([]string{"id", "title", "description", "photo", "published_by", "created_at", "updated_at", "version", "rank", "headline", "snippet"}).
+			AddRow(uuid.New(), "go news", "about go", uuid.Nil, uuid.New(), time.Now(), time.Now(), 1, 0.6, "<mark>go</mark> news", "about <mark>go</mark>")
+		mock.ExpectQuery(searchQuery).WithArgs("go -java", 2, 2).WillReturnRows(rows)
+
+		newsList, err := newsRepo.Search(context.Background(), "go -java", &utils.PaginationQuery{Page: 2, Size: 2})
+		require.NoError(t, err)
+		require.Equal(t, 3, newsList.TotalCount)
+		require.Equal(t, 2, newsList.TotalPages)
+		require.Len(t, newsList.News, 1)
+		require.Equal(t, 0.6, newsList.News[0].Rank)
+		require.Equal(t, "<mark>go</mark> news", newsList.News[0].Headline)
+		require.Equal(t, "about <mark>go</mark>", newsList.News[0].Snippet)
+	})
+
+	t.Run("No matches", func(t *testing.T) {
+		// Query of stop characters only is empty tsquery, search select is skipped
+		mock.ExpectQuery(countQuery).WithArgs("!!!").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+		newsList, err := newsRepo.Search(context.Background(), "!!!", &utils.PaginationQuery{Page: 1, Size: 10})
+		require.NoError(t, err)
+		require.Zero(t, newsList.TotalCount)
+		require.NotNil(t, newsList.News)
+		require.Empty(t, newsList.News)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
@@ -12,12 +13,13 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/AliIsmoilov/golang_monolight/internal/models"
+	"github.com/AliIsmoilov/golang_monolight/pkg/utils"
 )
 
 func TestBlogsRepo_Create(t *testing.T) {
 	t.Parallel()
 
-	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
 	defer db.Close()
 
@@ -30,15 +32,16 @@ func TestBlogsRepo_Create(t *testing.T) {
 		newsUID := uuid.New()
 		title := "title"
 
-		rows := sqlmock.NewRows([]string{"id", "title"}).AddRow(newsUID, title)
+		rows := sqlmock.NewRows([]string{"id", "title", "published_by", "created_at"}).AddRow(newsUID, title, uuid.New(), time.Now())
 
 		blog := &models.Blog{
-			ID:        newsUID,
-			Title:     title,
-			CreatedAt: time.Now(),
+			ID:          newsUID,
+			Title:       title,
+			PublishedBy: uuid.New(),
+			CreatedAt:   time.Now(),
 		}
 
-		mock.ExpectQuery("").WithArgs(blog.ID, blog.Title).WillReturnRows(rows)
+		mock.ExpectQuery("INSERT INTO blogs").WithArgs(sqlmock.AnyArg(), blog.Title, blog.PublishedBy).WillReturnRows(rows)
 
 		createdBlog, err := commRepo.Create(context.Background(), blog)
 
@@ -57,7 +60,7 @@ func TestBlogsRepo_Create(t *testing.T) {
 			Title: title,
 		}
 
-		mock.ExpectQuery("").WithArgs(blog.ID, blog.Title).WillReturnError(createErr)
+		mock.ExpectQuery("INSERT INTO blogs").WithArgs(sqlmock.AnyArg(), blog.Title, blog.PublishedBy).WillReturnError(createErr)
 
 		createdToDo, err := commRepo.Create(context.Background(), blog)
 
@@ -69,7 +72,7 @@ func TestBlogsRepo_Create(t *testing.T) {
 func TestBlogsRepo_Update(t *testing.T) {
 	t.Parallel()
 
-	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
 	defer db.Close()
 
@@ -82,19 +85,21 @@ func TestBlogsRepo_Update(t *testing.T) {
 		blogID := uuid.New()
 		title := "title"
 
-		rows := sqlmock.NewRows([]string{"id", "title"}).AddRow(blogID, title)
+		rows := sqlmock.NewRows([]string{"id", "title", "published_by", "created_at", "version"}).AddRow(blogID, title, uuid.New(), time.Now(), 4)
 
 		blog := &models.Blog{
-			ID:    blogID,
-			Title: title,
+			ID:      blogID,
+			Title:   title,
+			Version: 3,
 		}
 
+		mock.ExpectQuery(`UPDATE blogs SET title = \$1, version = version \+ 1`).WithArgs(blog.Title, blog.ID, blog.Version).WillReturnRows(rows)
 		updatedBlog, err := commRepo.Update(context.Background(), blog)
-		mock.ExpectQuery("updateBlog").WithArgs(blog.ID, blog.Title).WillReturnRows(rows)
 
 		require.NoError(t, err)
 		require.NotNil(t, updatedBlog)
 		require.Equal(t, updatedBlog.ID, blog.ID)
+		require.Equal(t, 4, updatedBlog.Version)
 	})
 
 	t.Run("Update ERR", func(t *testing.T) {
@@ -106,6 +111,7 @@ func TestBlogsRepo_Update(t *testing.T) {
 			Title: title,
 		}
 
+		mock.ExpectQuery("UPDATE blogs").WithArgs(blog.Title, blog.ID, blog.Version).WillReturnError(sql.ErrNoRows)
 		updatedBlog, err := commRepo.Update(context.Background(), blog)
 
 		require.NotNil(t, err)
@@ -116,7 +122,7 @@ func TestBlogsRepo_Update(t *testing.T) {
 func TestBlogsRepo_Delete(t *testing.T) {
 	t.Parallel()
 
-	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
 	defer db.Close()
 
@@ -127,7 +133,7 @@ func TestBlogsRepo_Delete(t *testing.T) {
 
 	t.Run("Delete", func(t *testing.T) {
 		blogID := uuid.New()
-		mock.ExpectExec("deleteBlog").WithArgs(blogID).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("DELETE FROM blogs").WithArgs(blogID).WillReturnResult(sqlmock.NewResult(1, 1))
 		err := commRepo.Delete(context.Background(), blogID)
 
 		require.NoError(t, err)
@@ -136,9 +142,87 @@ func TestBlogsRepo_Delete(t *testing.T) {
 	t.Run("Delete Err", func(t *testing.T) {
 		blogID := uuid.New()
 
-		mock.ExpectExec("deleteBlog").WithArgs(blogID).WillReturnResult(sqlmock.NewResult(1, 0))
+		mock.ExpectExec("DELETE FROM blogs").WithArgs(blogID).WillReturnResult(sqlmock.NewResult(1, 0))
 
 		err := commRepo.Delete(context.Background(), blogID)
 		require.NotNil(t, err)
 	})
 }
+
+func TestBlogsRepo_SoftDelete(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	commRepo := NewToDosRepository(sqlxDB)
+
+	t.Run("SoftDelete", func(t *testing.T) {
+		blogID := uuid.New()
+		mock.ExpectExec(`UPDATE blogs SET deleted_at = now\(\), version = version \+ 1, updated_at = now\(\) WHERE id = \$1 AND deleted_at IS NULL`).WithArgs(blogID).WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := commRepo.SoftDelete(context.Background(), blogID)
+		require.NoError(t, err)
+	})
+
+	t.Run("SoftDelete already deleted", func(t *testing.T) {
+		blogID := uuid.New()
+		mock.ExpectExec("UPDATE blogs SET deleted_at").WithArgs(blogID).WillReturnResult(sqlmock.NewResult(1, 0))
+
+		err := commRepo.SoftDelete(context.Background(), blogID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBlogsRepo_Search(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	blogsRepo := NewToDosRepository(sqlxDB)
+	countQuery := `SELECT COUNT\(id\)\s+FROM blogs\s+WHERE deleted_at IS NULL AND search_vector @@ websearch_to_tsquery\('simple', \$1\)`
+	searchQuery := `SELECT id, title, published_by, created_at, updated_at, version,\s+` +
+		`ts_rank\(search_vector, q\) AS rank,\s+` +
+		`ts_headline\('simple', title, q, .+\) AS headline\s+` +
+		`FROM blogs, websearch_to_tsquery\('simple', \$1\) q\s+` +
+		`WHERE deleted_at IS NULL AND search_vector @@ q\s+` +
+		`ORDER BY rank DESC, created_at DESC\s+OFFSET \$2 LIMIT \$3`
+
+	t.Run("Search", func(t *testing.T) {
+		mock.ExpectQuery(countQuery).WithArgs(`"go blog"`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		rows := sqlmock.NewRows([]string{"id", "title", "published_by", "created_at", "updated_at", "version", "rank", "headline"}).
+			AddRow(uuid.New(), "go blog", uuid.New(), time.Now(), time.Now(), 1, 0.9, "<mark>go</mark> <mark>blog</mark>")
+		mock.ExpectQuery(searchQuery).WithArgs(`"go blog"`, 0, 10).WillReturnRows(rows)
+
+		blogsList, err := blogsRepo.Search(context.Background(), `"go blog"`, &utils.PaginationQuery{Page: 1, Size: 10})
+		require.NoError(t, err)
+		require.Equal(t, 1, blogsList.TotalCount)
+		require.Len(t, blogsList.Blogs, 1)
+		require.Equal(t, 0.9, blogsList.Blogs[0].Rank)
+		require.Equal(t, "<mark>go</mark> <mark>blog</mark>", blogsList.Blogs[0].Headline)
+	})
+
+	t.Run("No matches", func(t *testing.T) {
+		// Query of stop characters only is empty tsquery, search select is skipped
+		mock.ExpectQuery(countQuery).WithArgs("!!!").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+		blogsList, err := blogsRepo.Search(context.Background(), "!!!", &utils.PaginationQuery{Page: 1, Size: 10})
+		require.NoError(t, err)
+		require.Zero(t, blogsList.TotalCount)
+		require.NotNil(t, blogsList.Blogs)
+		require.Empty(t, blogsList.Blogs)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
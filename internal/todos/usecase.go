@@ -13,17 +13,28 @@ import (
 type UseCase interface {
 	Create(ctx context.Context, blog *models.Blog) (*models.Blog, error)
 	Update(ctx context.Context, blog *models.Blog) (*models.Blog, error)
+	Patch(ctx context.Context, blogID uuid.UUID, patch *models.BlogPatch, version int) (*models.Blog, error)
 	Delete(ctx context.Context, blogID uuid.UUID) error
-	GetByID(ctx context.Context, blogID uuid.UUID) (*models.Blog, error)
-	GetAll(ctx context.Context, title string, query *utils.PaginationQuery) (*models.BlogsList, error)
+	SoftDelete(ctx context.Context, blogID uuid.UUID) error
+	Restore(ctx context.Context, blogID uuid.UUID) (*models.Blog, error)
+	GetByID(ctx context.Context, blogID uuid.UUID, includeDeleted bool) (*models.Blog, error)
+	GetAll(ctx context.Context, filter *utils.ListFilter, query *utils.PaginationQuery) (*models.BlogsList, error)
+	GetTrash(ctx context.Context, filter *utils.ListFilter, query *utils.PaginationQuery) (*models.BlogsList, error)
+	Search(ctx context.Context, text string, query *utils.PaginationQuery) (*models.BlogsList, error)
+	Purge(ctx context.Context) (int64, error)
 }
 
 // News use case
 type NewsUseCase interface {
 	Create(ctx context.Context, News *models.News) (*models.News, error)
 	Update(ctx context.Context, News *models.News) (*models.News, error)
+	Patch(ctx context.Context, NewsID uuid.UUID, patch *models.NewsPatch, version int) (*models.News, error)
 	Delete(ctx context.Context, NewsID uuid.UUID) error
 	SoftDelete(ctx context.Context, NewsID uuid.UUID) error
-	GetByID(ctx context.Context, NewsID uuid.UUID) (*models.News, error)
-	GetAll(ctx context.Context, title string, query *utils.PaginationQuery) (*models.NewsList, error)
+	Restore(ctx context.Context, NewsID uuid.UUID) (*models.News, error)
+	GetByID(ctx context.Context, NewsID uuid.UUID, includeDeleted bool) (*models.News, error)
+	GetAll(ctx context.Context, filter *utils.ListFilter, query *utils.PaginationQuery) (*models.NewsList, error)
+	GetTrash(ctx context.Context, filter *utils.ListFilter, query *utils.PaginationQuery) (*models.NewsList, error)
+	Search(ctx context.Context, text string, query *utils.PaginationQuery) (*models.NewsList, error)
+	Purge(ctx context.Context) (int64, error)
 }
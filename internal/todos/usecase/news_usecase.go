@@ -2,44 +2,144 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
 
 	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/internal/media"
 	"github.com/AliIsmoilov/golang_monolight/internal/models"
 	"github.com/AliIsmoilov/golang_monolight/internal/todos"
+	"github.com/AliIsmoilov/golang_monolight/pkg/httpErrors"
 	"github.com/AliIsmoilov/golang_monolight/pkg/logger"
+	"github.com/AliIsmoilov/golang_monolight/pkg/metrics"
+	"github.com/AliIsmoilov/golang_monolight/pkg/tracing"
 	"github.com/AliIsmoilov/golang_monolight/pkg/utils"
 	"github.com/google/uuid"
 )
 
 // News UseCase
 type newsUC struct {
-	cfg      *config.Config
-	newsRepo todos.NewsRepository
-	logger   logger.Logger
+	cfg       *config.Config
+	newsRepo  todos.NewsRepository
+	mediaRepo media.Repository
+	metrics   *metrics.Metrics
+	logger    logger.Logger
 }
 
 // News UseCase constructor
-func NewNewsUseCase(cfg *config.Config, newsRepo todos.NewsRepository, logger logger.Logger) todos.NewsUseCase {
-	return &newsUC{cfg: cfg, newsRepo: newsRepo, logger: logger}
+func NewNewsUseCase(cfg *config.Config, newsRepo todos.NewsRepository, mediaRepo media.Repository, metrics *metrics.Metrics, logger logger.Logger) todos.NewsUseCase {
+	return &newsUC{cfg: cfg, newsRepo: newsRepo, mediaRepo: mediaRepo, metrics: metrics, logger: logger}
 }
 
 // CreateNews
 func (u *newsUC) Create(ctx context.Context, news *models.News) (*models.News, error) {
-	return u.newsRepo.Create(ctx, news)
+	ctx, span := tracing.Start(ctx, "newsUC.Create")
+	defer span.End()
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(err)
+	}
+
+	if !user.CanPublish() {
+		return nil, httpErrors.NewForbiddenError(httpErrors.PermissionDenied)
+	}
+
+	if err = u.checkPhoto(ctx, news.Photo); err != nil {
+		return nil, err
+	}
+
+	news.PublishedBy = user.ID
+
+	createdNews, err := u.newsRepo.Create(ctx, news)
+	if err != nil {
+		return nil, err
+	}
+	u.metrics.ContentEvent(metrics.ContentNews, metrics.EventCreated)
+
+	u.setPhotoURLs(createdNews)
+	return createdNews, nil
 }
 
 // Update news
 func (u *newsUC) Update(ctx context.Context, news *models.News) (*models.News, error) {
+	ctx, span := tracing.Start(ctx, "newsUC.Update")
+	defer span.End()
+
+	current, err := u.checkCanModify(ctx, news.ID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if news.Version != 0 && news.Version != current.Version {
+		return nil, httpErrors.NewPreconditionFailedError(httpErrors.PreconditionFailed)
+	}
+
+	if err = u.checkPhoto(ctx, news.Photo); err != nil {
+		return nil, err
+	}
+
+	// Version is checked again by update, news may be changed after it was read
 	updatedNews, err := u.newsRepo.Update(ctx, news)
 	if err != nil {
+		if news.Version != 0 && errors.Is(err, sql.ErrNoRows) {
+			return nil, httpErrors.NewPreconditionFailedError(httpErrors.PreconditionFailed)
+		}
 		return nil, err
 	}
 
+	u.setPhotoURLs(updatedNews)
 	return updatedNews, nil
 }
 
-// Delete news
+// Patch news with merge patch, zero version skips version check
+func (u *newsUC) Patch(ctx context.Context, newsID uuid.UUID, patch *models.NewsPatch, version int) (*models.News, error) {
+	ctx, span := tracing.Start(ctx, "newsUC.Patch")
+	defer span.End()
+
+	current, err := u.checkCanModify(ctx, newsID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if version != 0 && version != current.Version {
+		return nil, httpErrors.NewPreconditionFailedError(httpErrors.PreconditionFailed)
+	}
+
+	if patch.IsEmpty() {
+		u.setPhotoURLs(current)
+		return current, nil
+	}
+
+	if patch.Photo.Set && !patch.Photo.Null {
+		if err = u.checkPhoto(ctx, patch.Photo.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	patchedNews, err := u.newsRepo.Patch(ctx, newsID, patch, version)
+	if err != nil {
+		if version != 0 && errors.Is(err, sql.ErrNoRows) {
+			return nil, httpErrors.NewPreconditionFailedError(httpErrors.PreconditionFailed)
+		}
+		return nil, err
+	}
+
+	u.setPhotoURLs(patchedNews)
+	return patchedNews, nil
+}
+
+// Delete news, soft deleted news may be deleted as well
 func (u *newsUC) Delete(ctx context.Context, newsID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "newsUC.Delete")
+	defer span.End()
+
+	if _, err := u.checkCanModify(ctx, newsID, true); err != nil {
+		return err
+	}
 
 	if err := u.newsRepo.Delete(ctx, newsID); err != nil {
 		return err
@@ -48,23 +148,175 @@ func (u *newsUC) Delete(ctx context.Context, newsID uuid.UUID) error {
 	return nil
 }
 
-// Delete news
+// Soft delete news
 func (u *newsUC) SoftDelete(ctx context.Context, newsID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "newsUC.SoftDelete")
+	defer span.End()
+
+	if _, err := u.checkCanModify(ctx, newsID, false); err != nil {
+		return err
+	}
 
 	if err := u.newsRepo.SoftDelete(ctx, newsID); err != nil {
 		return err
 	}
+	u.metrics.ContentEvent(metrics.ContentNews, metrics.EventSoftDeleted)
+
+	return nil
+}
+
+// Restore soft deleted news
+func (u *newsUC) Restore(ctx context.Context, newsID uuid.UUID) (*models.News, error) {
+	ctx, span := tracing.Start(ctx, "newsUC.Restore")
+	defer span.End()
+
+	if _, err := u.checkCanModify(ctx, newsID, true); err != nil {
+		return nil, err
+	}
+
+	restoredNews, err := u.newsRepo.Restore(ctx, newsID)
+	if err != nil {
+		return nil, err
+	}
+	u.metrics.ContentEvent(metrics.ContentNews, metrics.EventRestored)
+
+	u.setPhotoURLs(restoredNews)
+	return restoredNews, nil
+}
+
+// GetByID news, only editors and admins may get soft deleted news
+func (u *newsUC) GetByID(ctx context.Context, newID uuid.UUID, includeDeleted bool) (*models.News, error) {
+	ctx, span := tracing.Start(ctx, "newsUC.GetByID")
+	defer span.End()
+
+	if includeDeleted {
+		if err := checkCanSeeDeleted(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	news, err := u.newsRepo.GetByID(ctx, newID, includeDeleted)
+	if err != nil {
+		return nil, err
+	}
+
+	u.setPhotoURLs(news)
+	return news, nil
+}
+
+// GetAll news, only editors and admins may list soft deleted news
+func (u *newsUC) GetAll(ctx context.Context, filter *utils.ListFilter, query *utils.PaginationQuery) (*models.NewsList, error) {
+	ctx, span := tracing.Start(ctx, "newsUC.GetAll")
+	defer span.End()
+
+	if filter.IncludeDeleted {
+		if err := checkCanSeeDeleted(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.withPhotoURLs(u.newsRepo.GetAll(ctx, filter, query))
+}
+
+// GetTrash lists soft deleted news, authors see only their own news
+func (u *newsUC) GetTrash(ctx context.Context, filter *utils.ListFilter, query *utils.PaginationQuery) (*models.NewsList, error) {
+	ctx, span := tracing.Start(ctx, "newsUC.GetTrash")
+	defer span.End()
+
+	if err := restrictTrashFilter(ctx, filter); err != nil {
+		return nil, err
+	}
+
+	return u.withPhotoURLs(u.newsRepo.GetTrash(ctx, filter, query))
+}
+
+// Purge hard deletes news which stayed in trash longer than retention, admins only
+func (u *newsUC) Purge(ctx context.Context) (int64, error) {
+	ctx, span := tracing.Start(ctx, "newsUC.Purge")
+	defer span.End()
+
+	if err := checkCanPurge(ctx); err != nil {
+		return 0, err
+	}
+
+	cutoff, err := purgeCutoff(u.cfg)
+	if err != nil {
+		return 0, err
+	}
+
+	return u.newsRepo.Purge(ctx, cutoff)
+}
+
+// Search news
+func (u *newsUC) Search(ctx context.Context, text string, query *utils.PaginationQuery) (*models.NewsList, error) {
+	ctx, span := tracing.Start(ctx, "newsUC.Search")
+	defer span.End()
+
+	return u.withPhotoURLs(u.newsRepo.Search(ctx, text, query))
+}
+
+// Only news author, editors and admins may modify it, returns current news
+func (u *newsUC) checkCanModify(ctx context.Context, newsID uuid.UUID, includeDeleted bool) (*models.News, error) {
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(err)
+	}
+
+	news, err := u.newsRepo.GetByID(ctx, newsID, includeDeleted)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.CanModify(news.PublishedBy) {
+		return nil, httpErrors.NewForbiddenError(httpErrors.PermissionDenied)
+	}
+
+	return news, nil
+}
+
+// News photo must be uploaded image, nil photo means news without photo
+func (u *newsUC) checkPhoto(ctx context.Context, photoID uuid.UUID) error {
+	if photoID == uuid.Nil {
+		return nil
+	}
+
+	photo, err := u.mediaRepo.GetByID(ctx, photoID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return httpErrors.NewBadRequestError(httpErrors.MediaNotFound)
+		}
+		return err
+	}
+
+	if !strings.HasPrefix(photo.ContentType, "image/") {
+		return httpErrors.NewBadRequestError(httpErrors.NotAllowedImageHeader)
+	}
 
 	return nil
 }
 
-// GetByID news
-func (u *newsUC) GetByID(ctx context.Context, newID uuid.UUID) (*models.News, error) {
+// Resolve news photo variants urls, variants are served as original until generated
+func (u *newsUC) setPhotoURLs(news ...*models.News) {
+	for _, n := range news {
+		if n.Photo == uuid.Nil {
+			continue
+		}
 
-	return u.newsRepo.GetByID(ctx, newID)
+		original := fmt.Sprintf("%s/%s", strings.TrimSuffix(u.cfg.Media.PublicURL, "/"), n.Photo)
+		n.PhotoURLs = &models.PhotoURLs{
+			Thumb:    original + "?variant=" + models.VariantThumb,
+			Card:     original + "?variant=" + models.VariantCard,
+			Full:     original + "?variant=" + models.VariantFull,
+			Original: original,
+		}
+	}
 }
 
-// GetAll news
-func (u *newsUC) GetAll(ctx context.Context, title string, query *utils.PaginationQuery) (*models.NewsList, error) {
-	return u.newsRepo.GetAll(ctx, title, query)
+func (u *newsUC) withPhotoURLs(newsList *models.NewsList, err error) (*models.NewsList, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	u.setPhotoURLs(newsList.News...)
+	return newsList, nil
 }
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+	"github.com/AliIsmoilov/golang_monolight/pkg/httpErrors"
+	"github.com/AliIsmoilov/golang_monolight/pkg/utils"
+)
+
+// Only editors and admins may see soft deleted items
+func checkCanSeeDeleted(ctx context.Context) error {
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return httpErrors.NewUnauthorizedError(err)
+	}
+	if !user.HasRole(models.RoleAdmin, models.RoleEditor) {
+		return httpErrors.NewForbiddenError(httpErrors.PermissionDenied)
+	}
+
+	return nil
+}
+
+// Editors and admins see whole trash, authors only their own items
+func restrictTrashFilter(ctx context.Context, filter *utils.ListFilter) error {
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return httpErrors.NewUnauthorizedError(err)
+	}
+
+	switch {
+	case user.HasRole(models.RoleAdmin, models.RoleEditor):
+	case user.HasRole(models.RoleAuthor):
+		filter.PublishedBy = &user.ID
+	default:
+		return httpErrors.NewForbiddenError(httpErrors.PermissionDenied)
+	}
+
+	return nil
+}
+
+// Only admins may purge trash
+func checkCanPurge(ctx context.Context) error {
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return httpErrors.NewUnauthorizedError(err)
+	}
+	if !user.HasRole(models.RoleAdmin) {
+		return httpErrors.NewForbiddenError(httpErrors.PermissionDenied)
+	}
+
+	return nil
+}
+
+// Items deleted before cutoff are purged. Zero retention disables purge, as it does for trash purger,
+// otherwise purge would hard delete whole trash.
+func purgeCutoff(cfg *config.Config) (time.Time, error) {
+	if cfg.Server.TrashRetention <= 0 {
+		return time.Time{}, httpErrors.NewRestError(http.StatusConflict, httpErrors.ErrTrashPurgeDisabled, nil)
+	}
+	return time.Now().Add(-time.Second * cfg.Server.TrashRetention), nil
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+	"github.com/AliIsmoilov/golang_monolight/internal/todos/repository"
+	"github.com/AliIsmoilov/golang_monolight/pkg/httpErrors"
+	"github.com/AliIsmoilov/golang_monolight/pkg/utils"
+)
+
+func adminCtx() context.Context {
+	admin := &models.User{ID: uuid.New(), Role: models.RoleAdmin}
+	return context.WithValue(context.Background(), utils.UserCtxKey{}, admin)
+}
+
+func TestNewsUC_Purge(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	newsRepo := repository.NewNewsRepository(sqlxDB)
+
+	t.Run("Zero retention", func(t *testing.T) {
+		// No delete is expected, zero retention disables purge
+		newsUC := NewNewsUseCase(&config.Config{}, newsRepo, nil, nil, nil)
+
+		_, err := newsUC.Purge(adminCtx())
+		status, _ := httpErrors.ErrorResponse(err)
+		require.Equal(t, http.StatusConflict, status)
+	})
+
+	t.Run("Purge", func(t *testing.T) {
+		newsUC := NewNewsUseCase(&config.Config{Server: config.ServerConfig{TrashRetention: 60}}, newsRepo, nil, nil, nil)
+		mock.ExpectExec(`DELETE FROM news WHERE deleted_at IS NOT NULL AND deleted_at < \$1`).
+			WithArgs(sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		purged, err := newsUC.Purge(adminCtx())
+		require.NoError(t, err)
+		require.Equal(t, int64(2), purged)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestToDosUC_Purge(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	blogsRepo := repository.NewToDosRepository(sqlxDB)
+
+	// No delete is expected, zero retention disables purge
+	todosUC := NewToDosUseCase(&config.Config{}, blogsRepo, nil, nil)
+	_, err = todosUC.Purge(adminCtx())
+	status, _ := httpErrors.ErrorResponse(err)
+	require.Equal(t, http.StatusConflict, status)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,11 +2,17 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
 
 	"github.com/AliIsmoilov/golang_monolight/config"
 	"github.com/AliIsmoilov/golang_monolight/internal/models"
 	"github.com/AliIsmoilov/golang_monolight/internal/todos"
+	"github.com/AliIsmoilov/golang_monolight/pkg/httpErrors"
 	"github.com/AliIsmoilov/golang_monolight/pkg/logger"
+	"github.com/AliIsmoilov/golang_monolight/pkg/metrics"
+	"github.com/AliIsmoilov/golang_monolight/pkg/tracing"
 	"github.com/AliIsmoilov/golang_monolight/pkg/utils"
 	"github.com/google/uuid"
 )
@@ -15,31 +21,103 @@ import (
 type todosUC struct {
 	cfg       *config.Config
 	blogsRepo todos.BlogRepository
+	metrics   *metrics.Metrics
 	logger    logger.Logger
 }
 
 // ToDos UseCase constructor
-func NewToDosUseCase(cfg *config.Config, blogsRepo todos.BlogRepository, logger logger.Logger) todos.UseCase {
-	return &todosUC{cfg: cfg, blogsRepo: blogsRepo, logger: logger}
+func NewToDosUseCase(cfg *config.Config, blogsRepo todos.BlogRepository, metrics *metrics.Metrics, logger logger.Logger) todos.UseCase {
+	return &todosUC{cfg: cfg, blogsRepo: blogsRepo, metrics: metrics, logger: logger}
 }
 
 // Create todo
 func (u *todosUC) Create(ctx context.Context, blog *models.Blog) (*models.Blog, error) {
-	return u.blogsRepo.Create(ctx, blog)
+	ctx, span := tracing.Start(ctx, "todosUC.Create")
+	defer span.End()
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(err)
+	}
+
+	if !user.CanPublish() {
+		return nil, httpErrors.NewForbiddenError(httpErrors.PermissionDenied)
+	}
+
+	blog.PublishedBy = user.ID
+
+	createdBlog, err := u.blogsRepo.Create(ctx, blog)
+	if err != nil {
+		return nil, err
+	}
+	u.metrics.ContentEvent(metrics.ContentBlogs, metrics.EventCreated)
+
+	return createdBlog, nil
 }
 
 // Update todo
 func (u *todosUC) Update(ctx context.Context, todo *models.Blog) (*models.Blog, error) {
+	ctx, span := tracing.Start(ctx, "todosUC.Update")
+	defer span.End()
+
+	current, err := u.checkCanModify(ctx, todo.ID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if todo.Version != 0 && todo.Version != current.Version {
+		return nil, httpErrors.NewPreconditionFailedError(httpErrors.PreconditionFailed)
+	}
+
+	// Version is checked again by update, blog may be changed after it was read
 	updatedToDo, err := u.blogsRepo.Update(ctx, todo)
 	if err != nil {
+		if todo.Version != 0 && errors.Is(err, sql.ErrNoRows) {
+			return nil, httpErrors.NewPreconditionFailedError(httpErrors.PreconditionFailed)
+		}
 		return nil, err
 	}
 
 	return updatedToDo, nil
 }
 
-// Delete todo
+// Patch blog with merge patch, zero version skips version check
+func (u *todosUC) Patch(ctx context.Context, blogID uuid.UUID, patch *models.BlogPatch, version int) (*models.Blog, error) {
+	ctx, span := tracing.Start(ctx, "todosUC.Patch")
+	defer span.End()
+
+	current, err := u.checkCanModify(ctx, blogID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if version != 0 && version != current.Version {
+		return nil, httpErrors.NewPreconditionFailedError(httpErrors.PreconditionFailed)
+	}
+
+	if patch.IsEmpty() {
+		return current, nil
+	}
+
+	patchedBlog, err := u.blogsRepo.Patch(ctx, blogID, patch, version)
+	if err != nil {
+		if version != 0 && errors.Is(err, sql.ErrNoRows) {
+			return nil, httpErrors.NewPreconditionFailedError(httpErrors.PreconditionFailed)
+		}
+		return nil, err
+	}
+
+	return patchedBlog, nil
+}
+
+// Delete todo, soft deleted blogs may be deleted as well
 func (u *todosUC) Delete(ctx context.Context, todoID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "todosUC.Delete")
+	defer span.End()
+
+	if _, err := u.checkCanModify(ctx, todoID, true); err != nil {
+		return err
+	}
 
 	if err := u.blogsRepo.Delete(ctx, todoID); err != nil {
 		return err
@@ -48,13 +126,121 @@ func (u *todosUC) Delete(ctx context.Context, todoID uuid.UUID) error {
 	return nil
 }
 
-// GetByID todo
-func (u *todosUC) GetByID(ctx context.Context, blogID uuid.UUID) (*models.Blog, error) {
+// Soft delete blog
+func (u *todosUC) SoftDelete(ctx context.Context, blogID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "todosUC.SoftDelete")
+	defer span.End()
+
+	if _, err := u.checkCanModify(ctx, blogID, false); err != nil {
+		return err
+	}
+
+	if err := u.blogsRepo.SoftDelete(ctx, blogID); err != nil {
+		return err
+	}
+	u.metrics.ContentEvent(metrics.ContentBlogs, metrics.EventSoftDeleted)
+
+	return nil
+}
+
+// Restore soft deleted blog
+func (u *todosUC) Restore(ctx context.Context, blogID uuid.UUID) (*models.Blog, error) {
+	ctx, span := tracing.Start(ctx, "todosUC.Restore")
+	defer span.End()
+
+	if _, err := u.checkCanModify(ctx, blogID, true); err != nil {
+		return nil, err
+	}
+
+	restoredBlog, err := u.blogsRepo.Restore(ctx, blogID)
+	if err != nil {
+		return nil, err
+	}
+	u.metrics.ContentEvent(metrics.ContentBlogs, metrics.EventRestored)
 
-	return u.blogsRepo.GetByID(ctx, blogID)
+	return restoredBlog, nil
 }
 
-// GetAll todos
-func (u *todosUC) GetAll(ctx context.Context, title string, query *utils.PaginationQuery) (*models.BlogsList, error) {
-	return u.blogsRepo.GetAll(ctx, title, query)
+// GetByID todo, only editors and admins may get soft deleted blogs
+func (u *todosUC) GetByID(ctx context.Context, blogID uuid.UUID, includeDeleted bool) (*models.Blog, error) {
+	ctx, span := tracing.Start(ctx, "todosUC.GetByID")
+	defer span.End()
+
+	if includeDeleted {
+		if err := checkCanSeeDeleted(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.blogsRepo.GetByID(ctx, blogID, includeDeleted)
+}
+
+// GetAll todos, only editors and admins may list soft deleted blogs
+func (u *todosUC) GetAll(ctx context.Context, filter *utils.ListFilter, query *utils.PaginationQuery) (*models.BlogsList, error) {
+	ctx, span := tracing.Start(ctx, "todosUC.GetAll")
+	defer span.End()
+
+	if filter.IncludeDeleted {
+		if err := checkCanSeeDeleted(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.blogsRepo.GetAll(ctx, filter, query)
+}
+
+// GetTrash lists soft deleted blogs, authors see only their own blogs
+func (u *todosUC) GetTrash(ctx context.Context, filter *utils.ListFilter, query *utils.PaginationQuery) (*models.BlogsList, error) {
+	ctx, span := tracing.Start(ctx, "todosUC.GetTrash")
+	defer span.End()
+
+	if err := restrictTrashFilter(ctx, filter); err != nil {
+		return nil, err
+	}
+
+	return u.blogsRepo.GetTrash(ctx, filter, query)
+}
+
+// Purge hard deletes blogs which stayed in trash longer than retention, admins only
+func (u *todosUC) Purge(ctx context.Context) (int64, error) {
+	ctx, span := tracing.Start(ctx, "todosUC.Purge")
+	defer span.End()
+
+	if err := checkCanPurge(ctx); err != nil {
+		return 0, err
+	}
+
+	cutoff, err := purgeCutoff(u.cfg)
+	if err != nil {
+		return 0, err
+	}
+
+	return u.blogsRepo.Purge(ctx, cutoff)
+}
+
+// Search todos
+func (u *todosUC) Search(ctx context.Context, text string, query *utils.PaginationQuery) (*models.BlogsList, error) {
+	ctx, span := tracing.Start(ctx, "todosUC.Search")
+	defer span.End()
+
+	return u.blogsRepo.Search(ctx, text, query)
+}
+
+// Only blog author, editors and admins may modify it, returns current blog
+func (u *todosUC) checkCanModify(ctx context.Context, blogID uuid.UUID, includeDeleted bool) (*models.Blog, error) {
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(err)
+	}
+
+	blog, err := u.blogsRepo.GetByID(ctx, blogID, includeDeleted)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.CanModify(blog.PublishedBy) {
+		return nil, httpErrors.NewForbiddenError(httpErrors.PermissionDenied)
+	}
+
+	return blog, nil
 }
@@ -0,0 +1,62 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/internal/todos"
+	"github.com/AliIsmoilov/golang_monolight/pkg/logger"
+)
+
+// Trash purger hard deletes news and blogs which stayed in trash longer than retention
+type TrashPurger struct {
+	cfg       *config.Config
+	newsRepo  todos.NewsRepository
+	blogsRepo todos.BlogRepository
+	logger    logger.Logger
+}
+
+// Trash purger constructor
+func NewTrashPurger(cfg *config.Config, newsRepo todos.NewsRepository, blogsRepo todos.BlogRepository, logger logger.Logger) *TrashPurger {
+	return &TrashPurger{cfg: cfg, newsRepo: newsRepo, blogsRepo: blogsRepo, logger: logger}
+}
+
+// Run purges trash every TrashPurgeInterval until ctx is done, zero interval or retention disables purger
+func (p *TrashPurger) Run(ctx context.Context) {
+	if p.cfg.Server.TrashPurgeInterval <= 0 || p.cfg.Server.TrashRetention <= 0 {
+		p.logger.Info("Trash purger is disabled")
+		return
+	}
+
+	ticker := time.NewTicker(time.Second * p.cfg.Server.TrashPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		p.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *TrashPurger) purge(ctx context.Context) {
+	deletedBefore := time.Now().Add(-time.Second * p.cfg.Server.TrashRetention)
+
+	purgedNews, err := p.newsRepo.Purge(ctx, deletedBefore)
+	if err != nil {
+		p.logger.Errorf("TrashPurger.purge.newsRepo.Purge: %v", err)
+	} else if purgedNews > 0 {
+		p.logger.Infof("Trash purger deleted %d news", purgedNews)
+	}
+
+	purgedBlogs, err := p.blogsRepo.Purge(ctx, deletedBefore)
+	if err != nil {
+		p.logger.Errorf("TrashPurger.purge.blogsRepo.Purge: %v", err)
+	} else if purgedBlogs > 0 {
+		p.logger.Infof("Trash purger deleted %d blogs", purgedBlogs)
+	}
+}
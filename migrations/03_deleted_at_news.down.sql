@@ -1 +1 @@
-ALTER TABLE news DROP COLUMN IF EXIST deleted_at;
\ No newline at end of file
+ALTER TABLE news DROP COLUMN IF EXISTS deleted_at;
\ No newline at end of file
@@ -0,0 +1,21 @@
+CREATE TABLE users
+(
+    id UUID PRIMARY KEY         DEFAULT uuid_generate_v4(),
+    first_name VARCHAR(32) NOT NULL CHECK ( first_name <> '' ),
+    last_name  VARCHAR(32) NOT NULL CHECK ( last_name <> '' ),
+    email      CITEXT UNIQUE NOT NULL CHECK ( email <> '' ),
+    password   VARCHAR(250) NOT NULL CHECK ( octet_length(password) <> 0 ),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TABLE sessions
+(
+    id UUID PRIMARY KEY         DEFAULT uuid_generate_v4(),
+    user_id       UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    refresh_token VARCHAR(64) UNIQUE NOT NULL,
+    expires_at    TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at    TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS sessions_user_id_idx ON sessions (user_id);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS blogs_published_by_idx;
+DROP INDEX IF EXISTS news_published_by_idx;
+
+ALTER TABLE blogs DROP COLUMN IF EXISTS published_by;
+
+ALTER TABLE users DROP COLUMN IF EXISTS role;
@@ -0,0 +1,7 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(16) NOT NULL DEFAULT 'reader'
+    CHECK ( role IN ('admin', 'editor', 'author', 'reader') );
+
+ALTER TABLE blogs ADD COLUMN IF NOT EXISTS published_by UUID;
+
+CREATE INDEX IF NOT EXISTS news_published_by_idx ON news (published_by);
+CREATE INDEX IF NOT EXISTS blogs_published_by_idx ON blogs (published_by);
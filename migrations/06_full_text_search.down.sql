@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS blogs_search_vector_idx;
+ALTER TABLE blogs DROP COLUMN IF EXISTS search_vector;
+
+DROP INDEX IF EXISTS news_search_vector_idx;
+ALTER TABLE news DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS news_created_at_id_idx ON news (created_at, id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS blogs_created_at_id_idx ON blogs (created_at, id);
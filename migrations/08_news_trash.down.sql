@@ -0,0 +1 @@
+DROP INDEX IF EXISTS news_deleted_at_idx;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS blogs_deleted_at_idx;
+DROP INDEX IF EXISTS blogs_created_at_id_idx;
+CREATE INDEX IF NOT EXISTS blogs_created_at_id_idx ON blogs (created_at, id);
+
+ALTER TABLE blogs DROP COLUMN IF EXISTS deleted_at;
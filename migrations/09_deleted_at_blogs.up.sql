@@ -0,0 +1,5 @@
+ALTER TABLE blogs ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+
+DROP INDEX IF EXISTS blogs_created_at_id_idx;
+CREATE INDEX IF NOT EXISTS blogs_created_at_id_idx ON blogs (created_at, id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS blogs_deleted_at_idx ON blogs (deleted_at) WHERE deleted_at IS NOT NULL;
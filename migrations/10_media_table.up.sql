@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS media
+(
+    id           UUID PRIMARY KEY,
+    owner_id     UUID                     NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    file_name    VARCHAR(255)             NOT NULL,
+    content_type VARCHAR(100)             NOT NULL,
+    size         BIGINT                   NOT NULL CHECK ( size > 0 ),
+    storage_key  VARCHAR(512)             NOT NULL UNIQUE,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS media_owner_id_idx ON media (owner_id);
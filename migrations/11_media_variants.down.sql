@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS media_variants CASCADE;
+
+DROP INDEX IF EXISTS media_processing_idx;
+DROP INDEX IF EXISTS media_pending_idx;
+ALTER TABLE media DROP COLUMN IF EXISTS claimed_at;
+ALTER TABLE media DROP COLUMN IF EXISTS status;
@@ -0,0 +1,19 @@
+ALTER TABLE media ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'pending'
+    CHECK ( status IN ('pending', 'processing', 'ready', 'failed') );
+ALTER TABLE media ADD COLUMN IF NOT EXISTS claimed_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS media_pending_idx ON media (created_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS media_processing_idx ON media (claimed_at) WHERE status = 'processing';
+
+CREATE TABLE IF NOT EXISTS media_variants
+(
+    media_id     UUID                     NOT NULL REFERENCES media (id) ON DELETE CASCADE,
+    variant      VARCHAR(16)              NOT NULL,
+    content_type VARCHAR(100)             NOT NULL,
+    size         BIGINT                   NOT NULL,
+    width        INTEGER                  NOT NULL,
+    height       INTEGER                  NOT NULL,
+    storage_key  VARCHAR(512)             NOT NULL UNIQUE,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (media_id, variant)
+);
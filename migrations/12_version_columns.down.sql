@@ -0,0 +1,5 @@
+ALTER TABLE blogs DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE blogs DROP COLUMN IF EXISTS version;
+
+ALTER TABLE news DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE news DROP COLUMN IF EXISTS version;
@@ -0,0 +1,9 @@
+// Package migrations embeds versioned SQL migrations into the binary
+package migrations
+
+import "embed"
+
+// Migration files named <version>_<name>.<up|down>.sql
+//
+//go:embed *.sql
+var FS embed.FS
@@ -1,32 +1,56 @@
 package csrf
 
 import (
+	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
 	"io"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/AliIsmoilov/golang_monolight/pkg/httpErrors"
 	"github.com/AliIsmoilov/golang_monolight/pkg/logger"
 )
 
 const (
 	CSRFHeader = "X-CSRF-Token"
-	// 32 bytes
-	csrfSalt = "KbWaoi5xtDC3GEfBa9ovQdzOzXsuVU9I"
 )
 
-// Create CSRF token
-func MakeToken(sid string, logger logger.Logger) string {
-	hash := sha256.New()
-	_, err := io.WriteString(hash, csrfSalt+sid)
+// Create CSRF token tied to session id, token format is "<expires unix>.<signature>"
+func MakeToken(sid string, salt string, expire time.Duration, logger logger.Logger) string {
+	expiresAt := strconv.FormatInt(time.Now().Add(expire).Unix(), 10)
+	return expiresAt + "." + sign(sid, salt, expiresAt, logger)
+}
+
+// Validate CSRF token, returns ExpiredCSRFError or WrongCSRFToken
+func ValidateToken(token string, sid string, salt string, logger logger.Logger) error {
+	expiresAt, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return httpErrors.WrongCSRFToken
+	}
+
+	trueSignature := sign(sid, salt, expiresAt, logger)
+	if !hmac.Equal([]byte(signature), []byte(trueSignature)) {
+		return httpErrors.WrongCSRFToken
+	}
+
+	expires, err := strconv.ParseInt(expiresAt, 10, 64)
 	if err != nil {
-		logger.Errorf("Make CSRF Token", err)
+		return httpErrors.WrongCSRFToken
+	}
+	if time.Now().Unix() > expires {
+		return httpErrors.ExpiredCSRFError
 	}
-	token := base64.RawStdEncoding.EncodeToString(hash.Sum(nil))
-	return token
+
+	return nil
 }
 
-// Validate CSRF token
-func ValidateToken(token string, sid string, logger logger.Logger) bool {
-	trueToken := MakeToken(sid, logger)
-	return token == trueToken
+func sign(sid string, salt string, expiresAt string, logger logger.Logger) string {
+	hash := hmac.New(sha256.New, []byte(salt))
+	_, err := io.WriteString(hash, sid+"."+expiresAt)
+	if err != nil {
+		logger.Errorf("Make CSRF Token: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(hash.Sum(nil))
 }
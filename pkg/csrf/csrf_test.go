@@ -0,0 +1,43 @@
+package csrf
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/AliIsmoilov/golang_monolight/pkg/httpErrors"
+)
+
+func TestValidateToken(t *testing.T) {
+	t.Parallel()
+
+	const (
+		sid  = "5c9a9d67-ad38-499c-9858-086bfdeaf7d2"
+		salt = "salt"
+	)
+
+	t.Run("Valid", func(t *testing.T) {
+		token := MakeToken(sid, salt, time.Minute, nil)
+		require.NoError(t, ValidateToken(token, sid, salt, nil))
+	})
+
+	t.Run("Other session", func(t *testing.T) {
+		token := MakeToken(sid, salt, time.Minute, nil)
+		require.ErrorIs(t, ValidateToken(token, "other", salt, nil), httpErrors.WrongCSRFToken)
+	})
+
+	t.Run("Other salt", func(t *testing.T) {
+		token := MakeToken(sid, salt, time.Minute, nil)
+		require.ErrorIs(t, ValidateToken(token, sid, "other", nil), httpErrors.WrongCSRFToken)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		require.ErrorIs(t, ValidateToken("token", sid, salt, nil), httpErrors.WrongCSRFToken)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		token := MakeToken(sid, salt, -time.Minute, nil)
+		require.ErrorIs(t, ValidateToken(token, sid, salt, nil), httpErrors.ExpiredCSRFError)
+	})
+}
@@ -0,0 +1,330 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// NilVersion means no migration is applied
+const NilVersion = -1
+
+// Advisory lock key, shared by all replicas migrating the same database
+const lockKey int64 = 7_356_021_811
+
+var (
+	ErrDirty          = errors.New("database is dirty, fix it and force version")
+	ErrUnknownVersion = errors.New("unknown migration version")
+)
+
+var fileNameRegex = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Versioned SQL migration
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Current schema version and migrations state
+type Status struct {
+	Version    int
+	Dirty      bool
+	Migrations []*MigrationStatus
+}
+
+// Migration state
+type MigrationStatus struct {
+	Version int
+	Name    string
+	Applied bool
+}
+
+// Migrator applies migrations and tracks schema version in golang-migrate compatible schema_migrations table
+type Migrator struct {
+	db         *sqlx.DB
+	migrations []*Migration
+}
+
+// Migrator constructor, reads migrations from fsys root
+func NewMigrator(db *sqlx.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := ReadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Read migrations from fsys root sorted by version
+func ReadMigrations(fsys fs.FS) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, errors.Wrap(err, "migrate.ReadMigrations.ReadDir")
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		matches := fileNameRegex.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || matches == nil {
+			continue
+		}
+
+		version, err := strconv.Atoi(matches[1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "migrate.ReadMigrations.Atoi %s", entry.Name())
+		}
+		body, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, errors.Wrap(err, "migrate.ReadMigrations.ReadFile")
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: matches[2]}
+			byVersion[version] = m
+		} else if m.Name != matches[2] {
+			return nil, errors.Errorf("migrate.ReadMigrations: duplicate version %d", version)
+		}
+
+		if matches[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, errors.Errorf("migrate.ReadMigrations: version %d has no up migration", m.Version)
+		}
+		migrations = append(migrations, m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// Up applies all pending migrations, returns applied migrations
+func (m *Migrator) Up(ctx context.Context) ([]*Migration, error) {
+	var applied []*Migration
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		version, err := currentCleanVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if migration.Version <= version {
+				continue
+			}
+			if err = apply(ctx, conn, migration.Up, migration.Version); err != nil {
+				return errors.Wrapf(err, "migrate.Up version %d", migration.Version)
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down reverts up to steps latest applied migrations, returns reverted migrations
+func (m *Migrator) Down(ctx context.Context, steps int) ([]*Migration, error) {
+	var reverted []*Migration
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		version, err := currentCleanVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for ; steps > 0 && version != NilVersion; steps-- {
+			idx := m.indexOf(version)
+			if idx < 0 {
+				return errors.Wrapf(ErrUnknownVersion, "migrate.Down version %d", version)
+			}
+
+			prevVersion := NilVersion
+			if idx > 0 {
+				prevVersion = m.migrations[idx-1].Version
+			}
+			if err = apply(ctx, conn, m.migrations[idx].Down, prevVersion); err != nil {
+				return errors.Wrapf(err, "migrate.Down version %d", version)
+			}
+
+			reverted = append(reverted, m.migrations[idx])
+			version = prevVersion
+		}
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Force sets schema version and clears dirty flag without running migrations
+func (m *Migrator) Force(ctx context.Context, version int) error {
+	if version != NilVersion && m.indexOf(version) < 0 {
+		return errors.Wrapf(ErrUnknownVersion, "migrate.Force version %d", version)
+	}
+
+	return m.withLock(ctx, func(conn *sqlx.Conn) error {
+		tx, err := conn.BeginTxx(ctx, nil)
+		if err != nil {
+			return errors.Wrap(err, "migrate.Force.BeginTxx")
+		}
+		defer tx.Rollback()
+
+		if err = setVersion(ctx, tx, version); err != nil {
+			return err
+		}
+		return errors.Wrap(tx.Commit(), "migrate.Force.Commit")
+	})
+}
+
+// Status returns current schema version and applied migrations
+func (m *Migrator) Status(ctx context.Context) (*Status, error) {
+	status := &Status{}
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		version, dirty, err := currentVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		status.Version = version
+		status.Dirty = dirty
+		status.Migrations = make([]*MigrationStatus, 0, len(m.migrations))
+		for _, migration := range m.migrations {
+			status.Migrations = append(status.Migrations, &MigrationStatus{
+				Version: migration.Version,
+				Name:    migration.Name,
+				Applied: migration.Version <= version,
+			})
+		}
+		return nil
+	})
+
+	return status, err
+}
+
+// Latest embedded migration version, schema is up to date when it is applied
+func (m *Migrator) Latest() int {
+	if len(m.migrations) == 0 {
+		return NilVersion
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version reads current schema version without lock, so it does not wait for running migrations
+func (m *Migrator) Version(ctx context.Context) (int, bool, error) {
+	var (
+		version int
+		dirty   bool
+	)
+	err := m.db.QueryRowxContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return NilVersion, false, nil
+	}
+	if err != nil {
+		return 0, false, errors.Wrap(err, "migrate.Version.Scan")
+	}
+	return version, dirty, nil
+}
+
+func (m *Migrator) indexOf(version int) int {
+	for i, migration := range m.migrations {
+		if migration.Version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+// Session advisory lock keeps concurrently starting replicas from migrating at once
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sqlx.Conn) error) error {
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return errors.Wrap(err, "migrate.withLock.Connx")
+	}
+	defer conn.Close()
+
+	if _, err = conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockKey); err != nil {
+		return errors.Wrap(err, "migrate.withLock.pg_advisory_lock")
+	}
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, lockKey) //nolint:errcheck
+
+	createTable := `
+		CREATE TABLE IF NOT EXISTS schema_migrations
+		(
+			version BIGINT  NOT NULL PRIMARY KEY,
+			dirty   BOOLEAN NOT NULL
+		)`
+	if _, err = conn.ExecContext(ctx, createTable); err != nil {
+		return errors.Wrap(err, "migrate.withLock.createTable")
+	}
+
+	return fn(conn)
+}
+
+func currentVersion(ctx context.Context, conn *sqlx.Conn) (int, bool, error) {
+	var (
+		version int
+		dirty   bool
+	)
+	err := conn.QueryRowxContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return NilVersion, false, nil
+	}
+	if err != nil {
+		return 0, false, errors.Wrap(err, "migrate.currentVersion.Scan")
+	}
+	return version, dirty, nil
+}
+
+func currentCleanVersion(ctx context.Context, conn *sqlx.Conn) (int, error) {
+	version, dirty, err := currentVersion(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+	if dirty {
+		return 0, errors.Wrapf(ErrDirty, "version %d", version)
+	}
+	return version, nil
+}
+
+// Migration and its version are committed in one transaction, so failed migration leaves no changes
+func apply(ctx context.Context, conn *sqlx.Conn, query string, version int) error {
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "migrate.apply.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, query); err != nil {
+		return errors.Wrap(err, "migrate.apply.ExecContext")
+	}
+	if err = setVersion(ctx, tx, version); err != nil {
+		return err
+	}
+
+	return errors.Wrap(tx.Commit(), "migrate.apply.Commit")
+}
+
+func setVersion(ctx context.Context, tx *sqlx.Tx, version int) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations`); err != nil {
+		return errors.Wrap(err, "migrate.setVersion.Delete")
+	}
+	if version == NilVersion {
+		return nil
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)`, version); err != nil {
+		return errors.Wrap(err, "migrate.setVersion.Insert")
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package migrate
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AliIsmoilov/golang_monolight/migrations"
+)
+
+var testFS = fstest.MapFS{
+	"01_users.up.sql":   {Data: []byte("CREATE TABLE users (id INT);")},
+	"01_users.down.sql": {Data: []byte("DROP TABLE users;")},
+	"02_news.up.sql":    {Data: []byte("CREATE TABLE news (id INT);")},
+	"02_news.down.sql":  {Data: []byte("DROP TABLE news;")},
+	"migrations.go":     {Data: []byte("package migrations")},
+}
+
+func TestReadMigrations(t *testing.T) {
+	t.Parallel()
+
+	migrations, err := ReadMigrations(testFS)
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+	require.Equal(t, 1, migrations[0].Version)
+	require.Equal(t, "users", migrations[0].Name)
+	require.Equal(t, "DROP TABLE news;", migrations[1].Down)
+
+	_, err = ReadMigrations(fstest.MapFS{"03_x.down.sql": {Data: []byte("SELECT 1")}})
+	require.Error(t, err)
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	t.Parallel()
+
+	embedded, err := ReadMigrations(migrations.FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, embedded)
+	for _, m := range embedded {
+		require.NotEmpty(t, m.Down, "version %d has no down migration", m.Version)
+	}
+}
+
+func TestMigrator_Up(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	migrator, err := NewMigrator(sqlx.NewDb(db, "sqlmock"), testFS)
+	require.NoError(t, err)
+
+	mock.ExpectExec(`SELECT pg_advisory_lock`).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS schema_migrations`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT version, dirty FROM schema_migrations`).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(1, false))
+	mock.ExpectBegin()
+	mock.ExpectExec(`CREATE TABLE news`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DELETE FROM schema_migrations`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO schema_migrations`).WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec(`SELECT pg_advisory_unlock`).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	applied, err := migrator.Up(context.Background())
+	require.NoError(t, err)
+	require.Len(t, applied, 1)
+	require.Equal(t, 2, applied[0].Version)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Dirty(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	migrator, err := NewMigrator(sqlx.NewDb(db, "sqlmock"), testFS)
+	require.NoError(t, err)
+
+	mock.ExpectExec(`SELECT pg_advisory_lock`).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS schema_migrations`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT version, dirty FROM schema_migrations`).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(2, true))
+	mock.ExpectExec(`SELECT pg_advisory_unlock`).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	_, err = migrator.Down(context.Background(), 1)
+	require.ErrorIs(t, err, ErrDirty)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
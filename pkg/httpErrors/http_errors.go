@@ -19,6 +19,8 @@ const (
 	ErrUnauthorized       = "Unauthorized"
 	ErrForbidden          = "Forbidden"
 	ErrBadQueryParams     = "Invalid query params"
+	ErrFileTooLarge       = "File is too large"
+	ErrTrashPurgeDisabled = "Trash purge is disabled"
 )
 
 var (
@@ -40,6 +42,9 @@ var (
 	InvalidJWTClaims      = errors.New("invalid JWT claims")
 	NotAllowedImageHeader = errors.New("not allowed image header")
 	NoCookie              = errors.New("not found cookie header")
+	MediaNotFound         = errors.New("media not found")
+	TooManyRequests       = errors.New("too many requests")
+	PreconditionFailed    = errors.New("precondition failed")
 )
 
 // Rest error interface
@@ -134,6 +139,24 @@ func NewForbiddenError(causes interface{}) RestErr {
 	}
 }
 
+// New Precondition Failed Error
+func NewPreconditionFailedError(causes interface{}) RestErr {
+	return RestError{
+		ErrStatus: http.StatusPreconditionFailed,
+		ErrError:  PreconditionFailed.Error(),
+		ErrCauses: causes,
+	}
+}
+
+// New Too Many Requests Error
+func NewTooManyRequestsError(causes interface{}) RestErr {
+	return RestError{
+		ErrStatus: http.StatusTooManyRequests,
+		ErrError:  TooManyRequests.Error(),
+		ErrCauses: causes,
+	}
+}
+
 // New Internal Server Error
 func NewInternalServerError(causes interface{}) RestErr {
 	result := RestError{
@@ -146,9 +169,16 @@ func NewInternalServerError(causes interface{}) RestErr {
 
 // Parser of error string messages returns RestError
 func ParseErrors(err error) RestErr {
+	var restErr RestErr
+	if errors.As(err, &restErr) {
+		return restErr
+	}
+
 	switch {
 	case errors.Is(err, sql.ErrNoRows):
 		return NewRestError(http.StatusNotFound, NotFound.Error(), err)
+	case errors.Is(err, BadQueryParams):
+		return NewRestError(http.StatusBadRequest, BadQueryParams.Error(), err)
 	case errors.Is(err, context.DeadlineExceeded):
 		return NewRestError(http.StatusRequestTimeout, RequestTimeoutError.Error(), err)
 	case strings.Contains(err.Error(), "SQLSTATE"):
@@ -159,6 +189,8 @@ func ParseErrors(err error) RestErr {
 		return NewRestError(http.StatusBadRequest, BadRequest.Error(), err)
 	case strings.Contains(err.Error(), "UUID"):
 		return NewRestError(http.StatusBadRequest, err.Error(), err)
+	case errors.Is(err, CSRFNotPresented), errors.Is(err, WrongCSRFToken), errors.Is(err, ExpiredCSRFError):
+		return NewRestError(http.StatusForbidden, err.Error(), err)
 	case strings.Contains(strings.ToLower(err.Error()), "cookie"):
 		return NewRestError(http.StatusUnauthorized, Unauthorized.Error(), err)
 	case strings.Contains(strings.ToLower(err.Error()), "token"):
@@ -166,9 +198,6 @@ func ParseErrors(err error) RestErr {
 	case strings.Contains(strings.ToLower(err.Error()), "bcrypt"):
 		return NewRestError(http.StatusBadRequest, BadRequest.Error(), err)
 	default:
-		if restErr, ok := err.(RestErr); ok {
-			return restErr
-		}
 		return NewInternalServerError(err)
 	}
 }
@@ -0,0 +1,72 @@
+package imaging
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	gifExtension       = 0x21
+	gifImageDescriptor = 0x2C
+	gifTrailer         = 0x3B
+	gifColorTableFlag  = 0x80
+)
+
+var errMalformedGIF = errors.New("imaging: malformed GIF")
+
+// Sum of pixels of all GIF frames, read from image descriptors without decoding frames.
+// Each frame is allocated by gif.DecodeAll, so logical screen size alone does not bound memory.
+func gifFramesPixels(data []byte) (int, error) {
+	// Header and logical screen descriptor
+	if len(data) < 13 {
+		return 0, errMalformedGIF
+	}
+	i := 13
+	if data[10]&gifColorTableFlag != 0 {
+		i += 3 << (data[10]&7 + 1)
+	}
+
+	pixels := 0
+	for i < len(data) {
+		switch data[i] {
+		case gifTrailer:
+			return pixels, nil
+		case gifExtension:
+			i += 2
+		case gifImageDescriptor:
+			if i+10 > len(data) {
+				return 0, errMalformedGIF
+			}
+			width := int(binary.LittleEndian.Uint16(data[i+5:]))
+			height := int(binary.LittleEndian.Uint16(data[i+7:]))
+			if pixels += width * height; pixels > MaxPixels {
+				return pixels, nil
+			}
+			flags := data[i+9]
+			i += 10
+			if flags&gifColorTableFlag != 0 {
+				i += 3 << (flags&7 + 1)
+			}
+			// LZW minimum code size
+			i++
+		default:
+			return 0, errMalformedGIF
+		}
+
+		// Data sub-blocks until zero size block
+		for {
+			if i >= len(data) {
+				return 0, errMalformedGIF
+			}
+			size := int(data[i])
+			i += 1 + size
+			if size == 0 {
+				break
+			}
+		}
+	}
+
+	// Decoder accepts GIF without trailer
+	return pixels, nil
+}
@@ -0,0 +1,175 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"github.com/pkg/errors"
+	"golang.org/x/image/bmp"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/tiff"
+	"golang.org/x/image/webp"
+)
+
+// Larger images are rejected before decoding, so small file can not allocate gigabytes
+const MaxPixels = 50_000_000
+
+const jpegQuality = 85
+
+// Originals are re-encoded only to drop metadata, so quality is kept higher than in variants
+const originalJPEGQuality = 95
+
+var ErrTooLarge = errors.New("image is too large")
+
+// Image decoders by sniffed content type
+var decoders = map[string]func(io.Reader) (image.Image, error){
+	"image/jpeg": jpeg.Decode,
+	"image/png":  png.Decode,
+	"image/gif":  gif.Decode,
+	"image/bmp":  bmp.Decode,
+	"image/tiff": tiff.Decode,
+	"image/webp": webp.Decode,
+}
+
+var configDecoders = map[string]func(io.Reader) (image.Config, error){
+	"image/jpeg": jpeg.DecodeConfig,
+	"image/png":  png.DecodeConfig,
+	"image/gif":  gif.DecodeConfig,
+	"image/bmp":  bmp.DecodeConfig,
+	"image/tiff": tiff.DecodeConfig,
+	"image/webp": webp.DecodeConfig,
+}
+
+// Decode image and apply EXIF orientation, so pixels are upright once metadata is gone
+func Decode(data []byte, contentType string) (image.Image, error) {
+	decode, ok := decoders[contentType]
+	if !ok {
+		return nil, errors.Errorf("imaging.Decode: unsupported content type %s", contentType)
+	}
+
+	cfg, err := configDecoders[contentType](bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "imaging.Decode.DecodeConfig")
+	}
+	if cfg.Width*cfg.Height > MaxPixels {
+		return nil, ErrTooLarge
+	}
+
+	img, err := decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "imaging.Decode")
+	}
+
+	if contentType == "image/jpeg" {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+
+	return img, nil
+}
+
+// Resize image to fit into width x height, crop fills the box and cuts overflow from center.
+// Images are never upscaled.
+func Resize(src image.Image, width, height int, crop bool) image.Image {
+	b := src.Bounds()
+	srcW, srcH := b.Dx(), b.Dy()
+
+	if crop {
+		// Cut the largest centered region with box aspect ratio
+		cropW, cropH := srcW, srcW*height/width
+		if cropH > srcH {
+			cropW, cropH = srcH*width/height, srcH
+		}
+		x0 := b.Min.X + (srcW-cropW)/2
+		y0 := b.Min.Y + (srcH-cropH)/2
+		b = image.Rect(x0, y0, x0+cropW, y0+cropH)
+		srcW, srcH = cropW, cropH
+	}
+
+	dstW, dstH := srcW, srcH
+	if dstW > width {
+		dstW, dstH = width, dstH*width/dstW
+	}
+	if dstH > height {
+		dstW, dstH = dstW*height/dstH, height
+	}
+	dstW, dstH = max(dstW, 1), max(dstH, 1)
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dstW, dstH))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Src, nil)
+	return dst
+}
+
+// Encode image as JPEG, or PNG when it has transparency. Encoded image has no metadata.
+func Encode(w io.Writer, img image.Image) (string, error) {
+	if opaque, ok := img.(interface{ Opaque() bool }); ok && !opaque.Opaque() {
+		if err := png.Encode(w, img); err != nil {
+			return "", errors.Wrap(err, "imaging.Encode.png")
+		}
+		return "image/png", nil
+	}
+
+	if err := jpeg.Encode(w, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return "", errors.Wrap(err, "imaging.Encode.jpeg")
+	}
+	return "image/jpeg", nil
+}
+
+// StripMetadata re-encodes image without EXIF and other metadata, keeping its format where encoder exists.
+// JPEG orientation is applied to pixels, GIF animation is kept. BMP and ICO have no metadata and are returned as is.
+func StripMetadata(data []byte, contentType string) ([]byte, string, error) {
+	var buf bytes.Buffer
+
+	switch contentType {
+	case "image/gif":
+		cfg, err := gif.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return nil, "", errors.Wrap(err, "imaging.StripMetadata.DecodeConfig")
+		}
+		if cfg.Width*cfg.Height > MaxPixels {
+			return nil, "", ErrTooLarge
+		}
+		// Every frame is allocated, so pixels of all frames are limited
+		pixels, err := gifFramesPixels(data)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "imaging.StripMetadata.gifFramesPixels")
+		}
+		if pixels > MaxPixels {
+			return nil, "", ErrTooLarge
+		}
+		g, err := gif.DecodeAll(bytes.NewReader(data))
+		if err != nil {
+			return nil, "", errors.Wrap(err, "imaging.StripMetadata.DecodeAll")
+		}
+		if err = gif.EncodeAll(&buf, g); err != nil {
+			return nil, "", errors.Wrap(err, "imaging.StripMetadata.EncodeAll")
+		}
+		return buf.Bytes(), contentType, nil
+	case "image/jpeg", "image/png", "image/webp", "image/tiff":
+	default:
+		return data, contentType, nil
+	}
+
+	img, err := Decode(data, contentType)
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch contentType {
+	case "image/jpeg":
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: originalJPEGQuality})
+	case "image/png":
+		err = png.Encode(&buf, img)
+	default:
+		// No WebP and TIFF encoders, such originals become JPEG or PNG
+		contentType, err = Encode(&buf, img)
+	}
+	if err != nil {
+		return nil, "", errors.Wrap(err, "imaging.StripMetadata.Encode")
+	}
+
+	return buf.Bytes(), contentType, nil
+}
@@ -0,0 +1,149 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/gif"
+	"image/jpeg"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResize(t *testing.T) {
+	t.Parallel()
+
+	src := image.NewNRGBA(image.Rect(0, 0, 400, 200))
+
+	t.Run("Fit", func(t *testing.T) {
+		require.Equal(t, image.Rect(0, 0, 100, 50), Resize(src, 100, 100, false).Bounds())
+	})
+
+	t.Run("Crop", func(t *testing.T) {
+		require.Equal(t, image.Rect(0, 0, 100, 100), Resize(src, 100, 100, true).Bounds())
+	})
+
+	t.Run("No upscale", func(t *testing.T) {
+		require.Equal(t, image.Rect(0, 0, 400, 200), Resize(src, 1000, 1000, false).Bounds())
+	})
+}
+
+func TestEncode(t *testing.T) {
+	t.Parallel()
+
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+
+	var buf bytes.Buffer
+	contentType, err := Encode(&buf, img)
+	require.NoError(t, err)
+	require.Equal(t, "image/png", contentType)
+
+	for x := 0; x < 2; x++ {
+		for y := 0; y < 2; y++ {
+			img.Set(x, y, color.White)
+		}
+	}
+	buf.Reset()
+	contentType, err = Encode(&buf, img)
+	require.NoError(t, err)
+	require.Equal(t, "image/jpeg", contentType)
+
+	decoded, err := Decode(buf.Bytes(), contentType)
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, 2, 2), decoded.Bounds())
+}
+
+func TestOrientation(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, 4, 2)), nil))
+	require.Equal(t, 1, jpegOrientation(buf.Bytes()))
+
+	// SOI, APP1 with big endian EXIF which has single orientation entry = 6, rest of JPEG
+	exif := []byte{
+		'E', 'x', 'i', 'f', 0, 0,
+		'M', 'M', 0, 42, 0, 0, 0, 8,
+		0, 1,
+		0x01, 0x12, 0, 3, 0, 0, 0, 1, 0, 6, 0, 0,
+		0, 0, 0, 0,
+	}
+	data := append([]byte{0xFF, jpegSOI, 0xFF, jpegAPP1, 0, byte(len(exif) + 2)}, exif...)
+	data = append(data, buf.Bytes()[2:]...)
+	require.Equal(t, 6, jpegOrientation(data))
+
+	img, err := Decode(data, "image/jpeg")
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, 2, 4), img.Bounds())
+}
+
+func TestStripMetadata(t *testing.T) {
+	t.Parallel()
+
+	t.Run("JPEG", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, 4, 2)), nil))
+
+		exif := []byte{
+			'E', 'x', 'i', 'f', 0, 0,
+			'M', 'M', 0, 42, 0, 0, 0, 8,
+			0, 1,
+			0x01, 0x12, 0, 3, 0, 0, 0, 1, 0, 6, 0, 0,
+			0, 0, 0, 0,
+		}
+		data := append([]byte{0xFF, jpegSOI, 0xFF, jpegAPP1, 0, byte(len(exif) + 2)}, exif...)
+		data = append(data, buf.Bytes()[2:]...)
+
+		stripped, contentType, err := StripMetadata(data, "image/jpeg")
+		require.NoError(t, err)
+		require.Equal(t, "image/jpeg", contentType)
+		require.False(t, bytes.Contains(stripped, []byte("Exif")))
+
+		img, err := Decode(stripped, contentType)
+		require.NoError(t, err)
+		require.Equal(t, image.Rect(0, 0, 2, 4), img.Bounds())
+	})
+
+	t.Run("Animated GIF", func(t *testing.T) {
+		frame := image.NewPaletted(image.Rect(0, 0, 2, 2), palette.Plan9)
+		var buf bytes.Buffer
+		require.NoError(t, gif.EncodeAll(&buf, &gif.GIF{Image: []*image.Paletted{frame, frame}, Delay: []int{10, 10}}))
+
+		stripped, contentType, err := StripMetadata(buf.Bytes(), "image/gif")
+		require.NoError(t, err)
+		require.Equal(t, "image/gif", contentType)
+
+		g, err := gif.DecodeAll(bytes.NewReader(stripped))
+		require.NoError(t, err)
+		require.Len(t, g.Image, 2)
+
+		pixels, err := gifFramesPixels(buf.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, 8, pixels)
+	})
+
+	t.Run("Without metadata", func(t *testing.T) {
+		data := []byte("BM not really bitmap")
+		stripped, contentType, err := StripMetadata(data, "image/bmp")
+		require.NoError(t, err)
+		require.Equal(t, "image/bmp", contentType)
+		require.Equal(t, data, stripped)
+	})
+}
+
+func TestStripMetadata_GIFBomb(t *testing.T) {
+	t.Parallel()
+
+	// Logical screen fits MaxPixels, but four full screen frames do not
+	data := []byte("GIF89a")
+	data = append(data, 0xA0, 0x0F, 0xA0, 0x0F, 0, 0, 0)
+	for i := 0; i < 4; i++ {
+		data = append(data, gifImageDescriptor, 0, 0, 0, 0, 0xA0, 0x0F, 0xA0, 0x0F, 0, 2, 0)
+	}
+	data = append(data, gifTrailer)
+
+	_, _, err := StripMetadata(data, "image/gif")
+	require.ErrorIs(t, err, ErrTooLarge)
+}
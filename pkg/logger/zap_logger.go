@@ -1,6 +1,8 @@
 package logger
 
 import (
+	"context"
+	"fmt"
 	"os"
 
 	"go.uber.org/zap"
@@ -24,11 +26,16 @@ type Logger interface {
 	DPanicf(template string, args ...interface{})
 	Fatal(args ...interface{})
 	Fatalf(template string, args ...interface{})
+	Level() string
+	SetLevel(level string) error
+	With(args ...interface{}) Logger
+	FromContext(ctx context.Context) Logger
 }
 
 // Logger
 type apiLogger struct {
 	cfg         *config.Config
+	level       zap.AtomicLevel
 	sugarLogger *zap.SugaredLogger
 }
 
@@ -37,6 +44,11 @@ func NewApiLogger(cfg *config.Config) *apiLogger {
 	return &apiLogger{cfg: cfg}
 }
 
+// Logger writing to given zap core, e.g. observer core in tests. Level must be the one core is enabled with.
+func NewCoreLogger(core zapcore.Core, level zap.AtomicLevel) *apiLogger {
+	return &apiLogger{level: level, sugarLogger: zap.New(core).Sugar()}
+}
+
 // For mapping config logger to app logger levels
 var loggerLevelMap = map[string]zapcore.Level{
 	"debug":  zapcore.DebugLevel,
@@ -64,7 +76,7 @@ func (l *apiLogger) InitLogger() {
 	logWriter := zapcore.AddSync(os.Stderr)
 
 	var encoderCfg zapcore.EncoderConfig
-	if l.cfg.Server.Mode == "Development" {
+	if l.cfg.Server.Mode == config.ModeDevelopment {
 		encoderCfg = zap.NewDevelopmentEncoderConfig()
 	} else {
 		encoderCfg = zap.NewProductionEncoderConfig()
@@ -84,7 +96,8 @@ func (l *apiLogger) InitLogger() {
 	}
 
 	encoderCfg.EncodeTime = zapcore.ISO8601TimeEncoder
-	core := zapcore.NewCore(encoder, logWriter, zap.NewAtomicLevelAt(logLevel))
+	l.level = zap.NewAtomicLevelAt(logLevel)
+	core := zapcore.NewCore(encoder, logWriter, l.level)
 	logger := zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1))
 
 	l.sugarLogger = logger.Sugar()
@@ -93,6 +106,46 @@ func (l *apiLogger) InitLogger() {
 	}
 }
 
+// Current logger level
+func (l *apiLogger) Level() string {
+	return l.level.Level().String()
+}
+
+// Change logger level at runtime
+func (l *apiLogger) SetLevel(level string) error {
+	logLevel, exist := loggerLevelMap[level]
+	if !exist {
+		return fmt.Errorf("unknown logger level %q", level)
+	}
+
+	l.level.SetLevel(logLevel)
+	return nil
+}
+
+// Logger with structured key value fields, e.g. With("media_id", id)
+func (l *apiLogger) With(args ...interface{}) Logger {
+	return &apiLogger{cfg: l.cfg, level: l.level, sugarLogger: l.sugarLogger.With(args...)}
+}
+
+// Logger with fields stored in ctx by ContextWithFields, e.g. request_id and user_id
+func (l *apiLogger) FromContext(ctx context.Context) Logger {
+	fields, _ := ctx.Value(fieldsCtxKey{}).([]interface{})
+	if len(fields) == 0 {
+		return l
+	}
+	return l.With(fields...)
+}
+
+type fieldsCtxKey struct{}
+
+// ContextWithFields returns ctx carrying logger key value fields in addition to already stored ones
+func ContextWithFields(ctx context.Context, args ...interface{}) context.Context {
+	fields, _ := ctx.Value(fieldsCtxKey{}).([]interface{})
+	merged := make([]interface{}, 0, len(fields)+len(args))
+	merged = append(append(merged, fields...), args...)
+	return context.WithValue(ctx, fieldsCtxKey{}, merged)
+}
+
 // Logger methods
 
 func (l *apiLogger) Debug(args ...interface{}) {
@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newObservedLogger() (*apiLogger, *observer.ObservedLogs) {
+	level := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	core, logs := observer.New(level)
+	return NewCoreLogger(core, level), logs
+}
+
+func TestApiLogger_FromContext(t *testing.T) {
+	t.Parallel()
+
+	l, logs := newObservedLogger()
+
+	ctx := ContextWithFields(context.Background(), "request_id", "req-1")
+	ctx = ContextWithFields(ctx, "user_id", "user-1")
+	l.FromContext(ctx).With("media_id", "media-1").Info("message")
+	l.FromContext(context.Background()).Info("plain")
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 2)
+	require.Equal(t, map[string]interface{}{
+		"request_id": "req-1",
+		"user_id":    "user-1",
+		"media_id":   "media-1",
+	}, entries[0].ContextMap())
+	require.Empty(t, entries[1].Context)
+}
+
+func TestApiLogger_SetLevel(t *testing.T) {
+	t.Parallel()
+
+	l, logs := newObservedLogger()
+	child := l.With("key", "value")
+
+	l.Debug("hidden")
+	require.NoError(t, l.SetLevel("debug"))
+	child.Debug("visible")
+
+	require.Equal(t, "debug", l.Level())
+	require.Equal(t, 1, logs.Len())
+	require.Error(t, l.SetLevel("verbose"))
+}
@@ -0,0 +1,106 @@
+package query
+
+import (
+	"strconv"
+	"strings"
+)
+
+// SQL WHERE and UPDATE SET clauses builder, user input only ever goes to bound arguments
+type Builder struct {
+	assignments []string
+	conditions  []string
+	args        []interface{}
+}
+
+// Builder constructor
+func New() *Builder {
+	return &Builder{}
+}
+
+// Add condition joined with AND, each "?" in condition is replaced with next positional argument
+func (b *Builder) Where(condition string, args ...interface{}) *Builder {
+	b.conditions = append(b.conditions, b.bind(condition, args))
+	return b
+}
+
+// Add condition only if ok is true
+func (b *Builder) WhereIf(ok bool, condition string, args ...interface{}) *Builder {
+	if !ok {
+		return b
+	}
+	return b.Where(condition, args...)
+}
+
+// Add UPDATE assignment, "?" placeholders are bound like in Where
+func (b *Builder) Set(assignment string, args ...interface{}) *Builder {
+	b.assignments = append(b.assignments, b.bind(assignment, args))
+	return b
+}
+
+// Add assignment only if ok is true
+func (b *Builder) SetIf(ok bool, assignment string, args ...interface{}) *Builder {
+	if !ok {
+		return b
+	}
+	return b.Set(assignment, args...)
+}
+
+// Number of assignments, so caller may skip UPDATE without changes
+func (b *Builder) Assignments() int {
+	return len(b.assignments)
+}
+
+// Bind argument and return its positional placeholder, used for OFFSET and LIMIT
+func (b *Builder) Arg(arg interface{}) string {
+	b.args = append(b.args, arg)
+	return "$" + strconv.Itoa(len(b.args))
+}
+
+// WHERE clause with leading space or empty string if there are no conditions
+func (b *Builder) WhereClause() string {
+	if len(b.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(b.conditions, " AND ")
+}
+
+// SET clause with leading space or empty string if there are no assignments
+func (b *Builder) SetClause() string {
+	if len(b.assignments) == 0 {
+		return ""
+	}
+	return " SET " + strings.Join(b.assignments, ", ")
+}
+
+// Bound arguments in placeholders order
+func (b *Builder) Args() []interface{} {
+	return b.args
+}
+
+// Copy builder, so count and page queries may share conditions
+func (b *Builder) Clone() *Builder {
+	return &Builder{
+		assignments: append([]string(nil), b.assignments...),
+		conditions:  append([]string(nil), b.conditions...),
+		args:        append([]interface{}(nil), b.args...),
+	}
+}
+
+func (b *Builder) bind(clause string, args []interface{}) string {
+	var sb strings.Builder
+	argIdx := 0
+	for _, r := range clause {
+		if r == '?' && argIdx < len(args) {
+			sb.WriteString(b.Arg(args[argIdx]))
+			argIdx++
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// Escape LIKE pattern special characters
+func EscapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,58 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Empty", func(t *testing.T) {
+		b := New()
+		require.Equal(t, "", b.WhereClause())
+		require.Empty(t, b.Args())
+	})
+
+	t.Run("Conditions", func(t *testing.T) {
+		b := New().
+			Where("deleted_at IS NULL").
+			WhereIf(false, "title = ?", "skipped").
+			Where("title ILIKE ?", "%a%").
+			Where("created_at BETWEEN ? AND ?", 1, 2)
+
+		require.Equal(t, " WHERE deleted_at IS NULL AND title ILIKE $1 AND created_at BETWEEN $2 AND $3", b.WhereClause())
+		require.Equal(t, []interface{}{"%a%", 1, 2}, b.Args())
+	})
+
+	t.Run("Assignments", func(t *testing.T) {
+		b := New().
+			SetIf(true, "title = ?", "title").
+			SetIf(false, "description = ?", "skipped").
+			Set("version = version + 1").
+			Where("id = ?", 7)
+
+		require.Equal(t, 2, b.Assignments())
+		require.Equal(t, " SET title = $1, version = version + 1", b.SetClause())
+		require.Equal(t, " WHERE id = $2", b.WhereClause())
+		require.Equal(t, []interface{}{"title", 7}, b.Args())
+	})
+
+	t.Run("Clone", func(t *testing.T) {
+		b := New().Where("title = ?", "title")
+		page := b.Clone()
+
+		require.Equal(t, "$2", page.Arg(10))
+		require.Len(t, b.Args(), 1)
+		require.Len(t, page.Args(), 2)
+	})
+
+	t.Run("Injection stays in arguments", func(t *testing.T) {
+		title := "%'; DROP TABLE news; --"
+		b := New().Where("title ILIKE ?", "%"+EscapeLike(title)+"%")
+
+		require.Equal(t, " WHERE title ILIKE $1", b.WhereClause())
+		require.Equal(t, []interface{}{`%\%'; DROP TABLE news; --%`}, b.Args())
+	})
+}
@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	// Bucket is full from this time and may be dropped
+	full time.Time
+}
+
+// In memory store, buckets are per process so limits are not shared between instances
+type memoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// In memory store constructor
+func NewMemoryStore() Store {
+	return &memoryStore{buckets: make(map[string]*bucket), now: time.Now}
+}
+
+// Take token for key
+func (s *memoryStore) Take(_ context.Context, key string, limit Limit) (*Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Requests), updated: now}
+		s.buckets[key] = b
+	}
+
+	tokens, allowed := take(b.tokens, now.Sub(b.updated), limit)
+	b.tokens, b.updated = tokens, now
+
+	result := newResult(allowed, tokens, limit)
+	b.full = now.Add(result.Reset)
+	return result, nil
+}
+
+// Close does nothing, memory store holds no connections
+func (s *memoryStore) Close() error {
+	return nil
+}
+
+// Drop full buckets, they are equal to missing ones
+func (s *memoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if !now.Before(b.full) {
+			delete(s.buckets, key)
+		}
+	}
+}
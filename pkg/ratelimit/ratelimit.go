@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+)
+
+// Limiter stores
+const (
+	StoreMemory = "memory"
+	StoreRedis  = "redis"
+)
+
+// Limit allows Requests per Period, bucket holds up to Requests tokens so bursts are allowed after idle time
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// Result of taking token from bucket
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Until bucket is full again
+	Reset time.Duration
+	// Until next token, zero when allowed
+	RetryAfter time.Duration
+}
+
+// Store keeps token buckets, safe for concurrent use
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (*Result, error)
+	Close() error
+}
+
+// NewStore creates store chosen by rateLimit.Store
+func NewStore(cfg *config.Config) (Store, error) {
+	switch cfg.RateLimit.Store {
+	case "", StoreMemory:
+		return NewMemoryStore(), nil
+	case StoreRedis:
+		client := redis.NewClient(&redis.Options{
+			Addr:     cfg.RateLimit.RedisAddr,
+			Password: cfg.RateLimit.RedisPassword,
+			DB:       cfg.RateLimit.RedisDB,
+		})
+		return NewRedisStore(client, redisKeyPrefix), nil
+	default:
+		return nil, errors.Errorf("unknown rate limit store %q", cfg.RateLimit.Store)
+	}
+}
+
+// Tokens added per second
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// Refill bucket for elapsed time and take one token if available
+func take(tokens float64, elapsed time.Duration, limit Limit) (float64, bool) {
+	capacity := float64(limit.Requests)
+	if elapsed > 0 {
+		tokens = math.Min(capacity, tokens+elapsed.Seconds()*limit.rate())
+	}
+	if tokens < 1 {
+		return tokens, false
+	}
+	return tokens - 1, true
+}
+
+func newResult(allowed bool, tokens float64, limit Limit) *Result {
+	rate := limit.rate()
+	result := &Result{
+		Allowed:   allowed,
+		Limit:     limit.Requests,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     time.Duration((float64(limit.Requests) - tokens) / rate * float64(time.Second)),
+	}
+	if !allowed {
+		result.RetryAfter = time.Duration((1 - tokens) / rate * float64(time.Second))
+	}
+	return result
+}
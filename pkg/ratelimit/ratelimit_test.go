@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_Take(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	store := NewMemoryStore().(*memoryStore)
+	store.now = func() time.Time { return now }
+	limit := Limit{Requests: 2, Period: 10 * time.Second}
+
+	for i := 1; i >= 0; i-- {
+		result, err := store.Take(context.Background(), "ip:1", limit)
+		require.NoError(t, err)
+		require.True(t, result.Allowed)
+		require.Equal(t, i, result.Remaining)
+	}
+
+	result, err := store.Take(context.Background(), "ip:1", limit)
+	require.NoError(t, err)
+	require.False(t, result.Allowed)
+	require.Equal(t, 5*time.Second, result.RetryAfter)
+	require.Equal(t, 10*time.Second, result.Reset)
+
+	// Other keys have their own buckets
+	result, err = store.Take(context.Background(), "ip:2", limit)
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+
+	now = now.Add(5 * time.Second)
+	result, err = store.Take(context.Background(), "ip:1", limit)
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+	require.Equal(t, 0, result.Remaining)
+
+	// Full buckets are dropped on sweep
+	now = now.Add(time.Hour)
+	_, err = store.Take(context.Background(), "ip:3", limit)
+	require.NoError(t, err)
+	require.Len(t, store.buckets, 1)
+}
+
+func TestRedisStore_Take(t *testing.T) {
+	t.Parallel()
+
+	server := miniredis.RunT(t)
+	store := NewRedisStore(redis.NewClient(&redis.Options{Addr: server.Addr()}), redisKeyPrefix)
+	defer store.Close()
+	limit := Limit{Requests: 2, Period: time.Hour}
+
+	for i := 1; i >= 0; i-- {
+		result, err := store.Take(context.Background(), "user:1", limit)
+		require.NoError(t, err)
+		require.True(t, result.Allowed)
+		require.Equal(t, i, result.Remaining)
+	}
+
+	result, err := store.Take(context.Background(), "user:1", limit)
+	require.NoError(t, err)
+	require.False(t, result.Allowed)
+	require.Equal(t, 2, result.Limit)
+	require.Greater(t, result.RetryAfter, 29*time.Minute)
+
+	require.True(t, server.Exists(redisKeyPrefix+"user:1"))
+	require.Greater(t, server.TTL(redisKeyPrefix+"user:1"), 59*time.Minute)
+}
@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+const redisKeyPrefix = "ratelimit:"
+
+// Refill and take are done in one script, so concurrent instances can not both take last token.
+// Bucket expires when it would be full again.
+var takeScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'updated')
+local tokens = tonumber(bucket[1])
+local updated = tonumber(bucket[2])
+if tokens == nil or updated == nil then
+	tokens = capacity
+	updated = now
+end
+
+if now > updated then
+	tokens = math.min(capacity, tokens + (now - updated) * rate)
+end
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil((capacity - tokens) / rate) + 1)
+return {allowed, tostring(tokens)}
+`)
+
+// Redis store, limits are shared between api instances
+type redisStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// Redis store constructor, works with any redis protocol compatible server
+func NewRedisStore(client redis.UniversalClient, prefix string) Store {
+	return &redisStore{client: client, prefix: prefix}
+}
+
+// Take token for key
+func (s *redisStore) Take(ctx context.Context, key string, limit Limit) (*Result, error) {
+	// Rate is tokens per millisecond, script works with millisecond timestamps
+	rate := limit.rate() / float64(time.Second/time.Millisecond)
+	now := time.Now().UnixMilli()
+
+	reply, err := takeScript.Run(ctx, s.client, []string{s.prefix + key}, limit.Requests, rate, now).Slice()
+	if err != nil {
+		return nil, errors.Wrap(err, "redisStore.Take.Run")
+	}
+	if len(reply) != 2 {
+		return nil, errors.Errorf("redisStore.Take: unexpected reply %v", reply)
+	}
+
+	allowed, _ := reply[0].(int64)
+	tokensStr, _ := reply[1].(string)
+	tokens, err := strconv.ParseFloat(tokensStr, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "redisStore.Take.ParseFloat")
+	}
+
+	return newResult(allowed == 1, tokens, limit), nil
+}
+
+// Close redis client
+func (s *redisStore) Close() error {
+	return s.client.Close()
+}
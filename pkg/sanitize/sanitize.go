@@ -13,8 +13,17 @@ func init() {
 	sanitizer = bluemonday.UGCPolicy()
 }
 
-// Sanitize json
+// Sanitize json, null values are removed
 func SanitizeJSON(s []byte) ([]byte, error) {
+	return sanitizeJSON(s, false)
+}
+
+// Sanitize JSON merge patch, null values are kept because they remove fields
+func SanitizeMergePatch(s []byte) ([]byte, error) {
+	return sanitizeJSON(s, true)
+}
+
+func sanitizeJSON(s []byte, keepNull bool) ([]byte, error) {
 	d := json.NewDecoder(bytes.NewReader(s))
 	d.UseNumber()
 	var i interface{}
@@ -22,11 +31,11 @@ func SanitizeJSON(s []byte) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	sanitize(i)
+	sanitize(i, keepNull)
 	return json.MarshalIndent(i, "", "    ")
 }
 
-func sanitize(data interface{}) {
+func sanitize(data interface{}, keepNull bool) {
 	switch d := data.(type) {
 	case map[string]interface{}:
 		for k, v := range d {
@@ -34,11 +43,13 @@ func sanitize(data interface{}) {
 			case string:
 				d[k] = sanitizer.Sanitize(tv)
 			case map[string]interface{}:
-				sanitize(tv)
+				sanitize(tv, keepNull)
 			case []interface{}:
-				sanitize(tv)
+				sanitize(tv, keepNull)
 			case nil:
-				delete(d, k)
+				if !keepNull {
+					delete(d, k)
+				}
 			}
 		}
 	case []interface{}:
@@ -50,11 +61,11 @@ func sanitize(data interface{}) {
 				}
 			case map[string]interface{}:
 				for _, t := range d {
-					sanitize(t)
+					sanitize(t, keepNull)
 				}
 			case []interface{}:
 				for _, t := range d {
-					sanitize(t)
+					sanitize(t, keepNull)
 				}
 			}
 		}
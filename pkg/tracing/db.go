@@ -0,0 +1,98 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DB wraps sqlx.DB query methods used by repositories with spans carrying SQL statement and row count
+type DB struct {
+	*sqlx.DB
+}
+
+// Traced DB constructor
+func WrapDB(db *sqlx.DB) *DB {
+	return &DB{DB: db}
+}
+
+func (db *DB) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	ctx, span := startQuery(ctx, query)
+	err := db.DB.GetContext(ctx, dest, query, args...)
+	if err == nil {
+		setRows(span, 1)
+	}
+	endQuery(span, err)
+	return err
+}
+
+func (db *DB) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	ctx, span := startQuery(ctx, query)
+	err := db.DB.SelectContext(ctx, dest, query, args...)
+	if err == nil {
+		if v := reflect.Indirect(reflect.ValueOf(dest)); v.Kind() == reflect.Slice {
+			setRows(span, int64(v.Len()))
+		}
+	}
+	endQuery(span, err)
+	return err
+}
+
+func (db *DB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := startQuery(ctx, query)
+	result, err := db.DB.ExecContext(ctx, query, args...)
+	if err == nil {
+		if rows, rowsErr := result.RowsAffected(); rowsErr == nil {
+			setRows(span, rows)
+		}
+	}
+	endQuery(span, err)
+	return result, err
+}
+
+// Row is read after span ends, so span covers query execution only
+func (db *DB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	ctx, span := startQuery(ctx, query)
+	row := db.DB.QueryRowContext(ctx, query, args...)
+	endQuery(span, row.Err())
+	return row
+}
+
+func (db *DB) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	ctx, span := startQuery(ctx, query)
+	row := db.DB.QueryRowxContext(ctx, query, args...)
+	endQuery(span, row.Err())
+	return row
+}
+
+func (db *DB) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	ctx, span := startQuery(ctx, query)
+	rows, err := db.DB.QueryxContext(ctx, query, args...)
+	endQuery(span, err)
+	return rows, err
+}
+
+func startQuery(ctx context.Context, query string) (context.Context, trace.Span) {
+	return Start(ctx, "db.query",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL, semconv.DBStatement(query)),
+	)
+}
+
+func setRows(span trace.Span, rows int64) {
+	span.SetAttributes(attribute.Int64("db.rows", rows))
+}
+
+func endQuery(span trace.Span, err error) {
+	if err != nil && err != sql.ErrNoRows {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,65 @@
+package tracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestMiddleware_DBSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer sqlDB.Close()
+	db := WrapDB(sqlx.NewDb(sqlDB, "sqlmock"))
+
+	mock.ExpectQuery(`SELECT COUNT\(id\) FROM news`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(`SELECT id FROM news`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+
+	e := echo.New()
+	e.Use(Middleware())
+	e.GET("/v1/news/list", func(c echo.Context) error {
+		ctx, span := Start(c.Request().Context(), "newsRepo.GetAll")
+		defer span.End()
+
+		var count int
+		if err := db.GetContext(ctx, &count, `SELECT COUNT(id) FROM news`); err != nil {
+			return err
+		}
+		var ids []int
+		if err := db.SelectContext(ctx, &ids, `SELECT id FROM news`); err != nil {
+			return err
+		}
+		return c.NoContent(http.StatusOK)
+	})
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/news/list", nil))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 4)
+
+	byName := make(map[string][]sdktrace.ReadOnlySpan)
+	for _, span := range spans {
+		byName[span.Name()] = append(byName[span.Name()], span)
+	}
+	server := byName["GET /v1/news/list"][0]
+	repo := byName["newsRepo.GetAll"][0]
+	require.Equal(t, server.SpanContext().SpanID(), repo.Parent().SpanID())
+
+	queries := byName["db.query"]
+	require.Len(t, queries, 2)
+	require.Equal(t, repo.SpanContext().SpanID(), queries[1].Parent().SpanID())
+	require.Contains(t, queries[1].Attributes(), attribute.String("db.statement", "SELECT id FROM news"))
+	require.Contains(t, queries[1].Attributes(), attribute.Int64("db.rows", 2))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
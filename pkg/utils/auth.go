@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+	"github.com/AliIsmoilov/golang_monolight/pkg/httpErrors"
+)
+
+// Get user from context
+func GetUserFromCtx(ctx context.Context) (*models.User, error) {
+	user, ok := ctx.Value(UserCtxKey{}).(*models.User)
+	if !ok {
+		return nil, httpErrors.Unauthorized
+	}
+
+	return user, nil
+}
+
+// Create JWT cookie with access token
+func CreateJWTCookie(cfg *config.Config, token string, expiresAt time.Time) *http.Cookie {
+	return &http.Cookie{
+		Name:     cfg.Server.CookieName,
+		Value:    token,
+		Path:     "/",
+		Expires:  expiresAt,
+		Secure:   cfg.Server.Mode != config.ModeDevelopment,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// Delete JWT cookie
+func DeleteJWTCookie(cfg *config.Config) *http.Cookie {
+	return &http.Cookie{
+		Name:     cfg.Server.CookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		Expires:  time.Unix(0, 0),
+		Secure:   cfg.Server.Mode != config.ModeDevelopment,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
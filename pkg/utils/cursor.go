@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/AliIsmoilov/golang_monolight/pkg/httpErrors"
+)
+
+// Keyset pagination cursor, points to the last item of previous page
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// Encode cursor to opaque url safe token
+func EncodeCursor(createdAt time.Time, id uuid.UUID) string {
+	raw := createdAt.UTC().Format(time.RFC3339Nano) + "," + id.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// Decode cursor token, invalid tokens are reported as BadQueryParams
+func DecodeCursor(token string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.Wrap(httpErrors.BadQueryParams, "cursor")
+	}
+
+	createdAtPart, idPart, ok := strings.Cut(string(raw), ",")
+	if !ok {
+		return nil, errors.Wrap(httpErrors.BadQueryParams, "cursor")
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, createdAtPart)
+	if err != nil {
+		return nil, errors.Wrap(httpErrors.BadQueryParams, "cursor")
+	}
+
+	id, err := uuid.Parse(idPart)
+	if err != nil {
+		return nil, errors.Wrap(httpErrors.BadQueryParams, "cursor")
+	}
+
+	return &Cursor{CreatedAt: createdAt, ID: id}, nil
+}
@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/AliIsmoilov/golang_monolight/pkg/httpErrors"
+)
+
+// Conditional request headers
+const (
+	HeaderETag        = "ETag"
+	HeaderIfMatch     = "If-Match"
+	HeaderIfNoneMatch = "If-None-Match"
+)
+
+// ETag of versioned resource, version is bumped on every change
+func VersionETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// IfMatchVersion reads expected version from If-Match, zero means no precondition.
+// Single strong etag is supported, "*" matches any existing resource.
+func IfMatchVersion(c echo.Context) (int, error) {
+	value := strings.TrimSpace(c.Request().Header.Get(HeaderIfMatch))
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+
+	// Weak etags never match If-Match, unquoted value is malformed
+	if !strings.HasPrefix(value, `"`) || !strings.HasSuffix(value, `"`) || len(value) < 2 {
+		return 0, httpErrors.NewPreconditionFailedError(HeaderIfMatch)
+	}
+	version, err := strconv.Atoi(value[1 : len(value)-1])
+	if err != nil || version <= 0 {
+		return 0, httpErrors.NewPreconditionFailedError(HeaderIfMatch)
+	}
+
+	return version, nil
+}
+
+// IfNoneMatch reports whether If-None-Match matches etag, weak comparison is used
+func IfNoneMatch(c echo.Context, etag string) bool {
+	for _, tag := range strings.Split(c.Request().Header.Get(HeaderIfNoneMatch), ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
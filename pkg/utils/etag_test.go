@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditionalHeaders(t *testing.T) {
+	t.Parallel()
+
+	newContext := func(header, value string) echo.Context {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(header, value)
+		return echo.New().NewContext(req, httptest.NewRecorder())
+	}
+
+	t.Run("IfMatchVersion", func(t *testing.T) {
+		version, err := IfMatchVersion(newContext(HeaderIfMatch, `"7"`))
+		require.NoError(t, err)
+		require.Equal(t, 7, version)
+
+		version, err = IfMatchVersion(newContext(HeaderIfMatch, "*"))
+		require.NoError(t, err)
+		require.Zero(t, version)
+
+		for _, value := range []string{`W/"7"`, "7", `"abc"`, `"0"`} {
+			_, err = IfMatchVersion(newContext(HeaderIfMatch, value))
+			require.Error(t, err, value)
+		}
+	})
+
+	t.Run("IfNoneMatch", func(t *testing.T) {
+		etag := VersionETag(3)
+		require.True(t, IfNoneMatch(newContext(HeaderIfNoneMatch, `"2", W/"3"`), etag))
+		require.True(t, IfNoneMatch(newContext(HeaderIfNoneMatch, "*"), etag))
+		require.False(t, IfNoneMatch(newContext(HeaderIfNoneMatch, `"2"`), etag))
+		require.False(t, IfNoneMatch(newContext(HeaderIfNoneMatch, ""), etag))
+	})
+}
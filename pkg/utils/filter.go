@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/AliIsmoilov/golang_monolight/pkg/httpErrors"
+)
+
+const dateLayout = "2006-01-02"
+
+// List filter query params
+type ListFilter struct {
+	Title          string     `json:"title,omitempty"`
+	PublishedBy    *uuid.UUID `json:"published_by,omitempty"`
+	CreatedFrom    *time.Time `json:"created_from,omitempty"`
+	CreatedTo      *time.Time `json:"created_to,omitempty"`
+	HasPhoto       *bool      `json:"has_photo,omitempty"`
+	IncludeDeleted bool       `json:"include_deleted,omitempty"`
+}
+
+// Get list filter from query params, invalid values are reported as BadQueryParams
+func GetListFilterFromCtx(c echo.Context) (*ListFilter, error) {
+	f := &ListFilter{Title: strings.TrimSpace(c.QueryParam("title"))}
+
+	if v := c.QueryParam("published_by"); v != "" {
+		publishedBy, err := uuid.Parse(v)
+		if err != nil {
+			return nil, errors.Wrap(httpErrors.BadQueryParams, "published_by")
+		}
+		f.PublishedBy = &publishedBy
+	}
+
+	if v := c.QueryParam("created_from"); v != "" {
+		createdFrom, _, err := parseFilterTime(v)
+		if err != nil {
+			return nil, errors.Wrap(httpErrors.BadQueryParams, "created_from")
+		}
+		f.CreatedFrom = &createdFrom
+	}
+
+	if v := c.QueryParam("created_to"); v != "" {
+		createdTo, dateOnly, err := parseFilterTime(v)
+		if err != nil {
+			return nil, errors.Wrap(httpErrors.BadQueryParams, "created_to")
+		}
+		// Date includes the whole day, postgres keeps microseconds
+		if dateOnly {
+			createdTo = createdTo.AddDate(0, 0, 1).Add(-time.Microsecond)
+		}
+		f.CreatedTo = &createdTo
+	}
+
+	if f.CreatedFrom != nil && f.CreatedTo != nil && f.CreatedFrom.After(*f.CreatedTo) {
+		return nil, errors.Wrap(httpErrors.BadQueryParams, "created_from is after created_to")
+	}
+
+	if v := c.QueryParam("has_photo"); v != "" {
+		hasPhoto, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, errors.Wrap(httpErrors.BadQueryParams, "has_photo")
+		}
+		f.HasPhoto = &hasPhoto
+	}
+
+	if v := c.QueryParam("include_deleted"); v != "" {
+		includeDeleted, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, errors.Wrap(httpErrors.BadQueryParams, "include_deleted")
+		}
+		f.IncludeDeleted = includeDeleted
+	}
+
+	return f, nil
+}
+
+// Accepts RFC3339 timestamp or date
+func parseFilterTime(v string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, false, nil
+	}
+	t, err := time.Parse(dateLayout, v)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return t, true, nil
+}
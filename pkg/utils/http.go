@@ -45,30 +45,30 @@ func GetConfigPath(configPath string) string {
 // UserCtxKey is a key used for the User object in the context
 type UserCtxKey struct{}
 
-// Get user from context
+// Get client ip resolved by echo IPExtractor, see NewIPExtractor
 func GetIPAddress(c echo.Context) string {
-	return c.Request().RemoteAddr
+	return c.RealIP()
+}
+
+// Request log fields shared by access and error logs, request_id and user_id come with logger.FromContext
+func RequestLogFields(ctx echo.Context) []interface{} {
+	return []interface{}{
+		"method", ctx.Request().Method,
+		"path", ctx.Request().URL.Path,
+		"ip", GetIPAddress(ctx),
+	}
 }
 
 // Error response with logging error for echo context
 func ErrResponseWithLog(ctx echo.Context, logger logger.Logger, err error) error {
-	logger.Errorf(
-		"ErrResponseWithLog, RequestID: %s, IPAddress: %s, Error: %s",
-		GetRequestID(ctx),
-		GetIPAddress(ctx),
-		err,
-	)
+	LogResponseError(ctx, logger, err)
 	return ctx.JSON(httpErrors.ErrorResponse(err))
 }
 
-// Error response with logging error for echo context
+// Log response error with request fields as structured keys, request_id and user_id come from request context
 func LogResponseError(ctx echo.Context, logger logger.Logger, err error) {
-	logger.Errorf(
-		"ErrResponseWithLog, RequestID: %s, IPAddress: %s, Error: %s",
-		GetRequestID(ctx),
-		GetIPAddress(ctx),
-		err,
-	)
+	status, _ := httpErrors.ErrorResponse(err)
+	logger.FromContext(ctx.Request().Context()).With(RequestLogFields(ctx)...).With("status", status, "error", err.Error()).Error("Response error")
 }
 
 // Read request body and validate
@@ -99,18 +99,55 @@ func SanitizeRequest(ctx echo.Context, request interface{}) error {
 	return validate.StructCtx(ctx.Request().Context(), request)
 }
 
+// Read sanitize and validate JSON merge patch, body must be object
+func ReadMergePatch(ctx echo.Context, patch interface{ Validate() error }) error {
+	body, err := ioutil.ReadAll(ctx.Request().Body)
+	if err != nil {
+		return err
+	}
+	defer ctx.Request().Body.Close()
+
+	sanBody, err := sanitize.SanitizeMergePatch(body)
+	if err != nil {
+		return httpErrors.NewBadRequestError(err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(sanBody, &fields); err != nil {
+		return httpErrors.NewBadRequestError("merge patch must be JSON object")
+	}
+
+	if err = json.Unmarshal(sanBody, patch); err != nil {
+		return httpErrors.NewBadRequestError(err)
+	}
+
+	if err = patch.Validate(); err != nil {
+		return httpErrors.NewRestError(http.StatusBadRequest, err.Error(), err)
+	}
+	return nil
+}
+
+// Allowed images, keys are the content types http.DetectContentType returns for images
 var allowedImagesContentTypes = map[string]string{
-	"image/bmp":                "bmp",
-	"image/gif":                "gif",
-	"image/png":                "png",
-	"image/jpeg":               "jpeg",
-	"image/jpg":                "jpg",
-	"image/svg+xml":            "svg",
-	"image/webp":               "webp",
-	"image/tiff":               "tiff",
-	"image/vnd.microsoft.icon": "ico",
+	"image/bmp":    "bmp",
+	"image/gif":    "gif",
+	"image/png":    "png",
+	"image/jpeg":   "jpeg",
+	"image/webp":   "webp",
+	"image/x-icon": "ico",
+}
+
+// Allowed images which are resized into variants, other images are served only as uploaded
+var resizableImagesContentTypes = map[string]bool{
+	"image/bmp":  true,
+	"image/gif":  true,
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/webp": true,
+	"image/tiff": true,
 }
 
+// Check image content type is allowed, returns file extension
 func CheckImageFileContentType(fileContent []byte) (string, error) {
 	contentType := http.DetectContentType(fileContent)
 
@@ -121,3 +158,8 @@ func CheckImageFileContentType(fileContent []byte) (string, error) {
 
 	return extension, nil
 }
+
+// Check image of content type may be resized
+func IsResizableImage(contentType string) bool {
+	return resizableImagesContentTypes[contentType]
+}
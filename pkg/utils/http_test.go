@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckImageFileContentType(t *testing.T) {
+	t.Parallel()
+
+	extension, err := CheckImageFileContentType([]byte("\x89PNG\x0D\x0A\x1A\x0A"))
+	require.NoError(t, err)
+	require.Equal(t, "png", extension)
+
+	extension, err = CheckImageFileContentType([]byte("\x00\x00\x01\x00"))
+	require.NoError(t, err)
+	require.Equal(t, "ico", extension)
+
+	_, err = CheckImageFileContentType([]byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`))
+	require.Error(t, err)
+}
@@ -0,0 +1,141 @@
+package utils
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+const headerForwarded = "Forwarded"
+
+// NewIPExtractor resolves client ip from Forwarded, X-Forwarded-For or X-Real-IP headers.
+// Headers are read only when request comes from trusted proxy, proxy chain is walked from the right
+// and first untrusted address is client. Without trusted proxies peer address is always used.
+func NewIPExtractor(trustedProxies []string) (echo.IPExtractor, error) {
+	nets, err := ParseTrustedProxies(trustedProxies)
+	if err != nil {
+		return nil, err
+	}
+	if len(nets) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	trusted := func(ip net.IP) bool {
+		for _, n := range nets {
+			if n.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(req *http.Request) string {
+		direct := directIP(req)
+		if ip := net.ParseIP(direct); ip == nil || !trusted(ip) {
+			return direct
+		}
+
+		if client, ok := clientFromChain(forwardedFor(req.Header.Values(headerForwarded)), trusted); ok {
+			return client
+		}
+		if client, ok := clientFromChain(splitList(req.Header.Values(echo.HeaderXForwardedFor)), trusted); ok {
+			return client
+		}
+		if realIP := parseIP(req.Header.Get(echo.HeaderXRealIP)); realIP != nil {
+			return realIP.String()
+		}
+		return direct
+	}, nil
+}
+
+// ParseTrustedProxies parses CIDRs, plain ip is single address network
+func ParseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, errors.Errorf("invalid trusted proxy %q", proxy)
+			}
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, n, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid trusted proxy %q", proxy)
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
+
+// Rightmost untrusted address, leftmost one when whole chain is trusted.
+// Unparsable entry stops the walk, addresses left of it can not be verified.
+func clientFromChain(chain []string, trusted func(net.IP) bool) (string, bool) {
+	for i := len(chain) - 1; i >= 0; i-- {
+		ip := parseIP(chain[i])
+		if ip == nil {
+			return "", false
+		}
+		if !trusted(ip) || i == 0 {
+			return ip.String(), true
+		}
+	}
+	return "", false
+}
+
+// for= values of RFC 7239 Forwarded header in order
+func forwardedFor(values []string) []string {
+	var chain []string
+	for _, element := range splitList(values) {
+		for _, pair := range strings.Split(element, ";") {
+			key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+			if ok && strings.EqualFold(key, "for") {
+				chain = append(chain, value)
+			}
+		}
+	}
+	return chain
+}
+
+func splitList(values []string) []string {
+	var list []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+	return list
+}
+
+// Parse ip with optional quotes, port and ipv6 brackets
+func parseIP(value string) net.IP {
+	value = strings.Trim(strings.TrimSpace(value), `"`)
+	if host, _, err := net.SplitHostPort(value); err == nil {
+		value = host
+	}
+	return net.ParseIP(strings.Trim(value, "[]"))
+}
+
+func directIP(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
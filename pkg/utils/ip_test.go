@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIPExtractor(t *testing.T) {
+	t.Parallel()
+
+	extractor, err := NewIPExtractor([]string{"10.0.0.0/8", "192.168.1.1"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string]string
+		expected   string
+	}{
+		{"Direct", "203.0.113.7:5000", nil, "203.0.113.7"},
+		{"UntrustedPeerHeadersIgnored", "203.0.113.7:5000", map[string]string{"X-Forwarded-For": "1.1.1.1"}, "203.0.113.7"},
+		{"XForwardedFor", "10.0.0.2:5000", map[string]string{"X-Forwarded-For": "1.1.1.1, 198.51.100.4, 10.0.0.5"}, "198.51.100.4"},
+		{"XForwardedForAllTrusted", "10.0.0.2:5000", map[string]string{"X-Forwarded-For": "10.0.0.9, 192.168.1.1"}, "10.0.0.9"},
+		{"XRealIP", "192.168.1.1:5000", map[string]string{"X-Real-IP": "198.51.100.4"}, "198.51.100.4"},
+		{"Forwarded", "10.0.0.2:5000", map[string]string{"Forwarded": `for="[2001:db8::1]:4711";proto=https, for=10.0.0.3`}, "2001:db8::1"},
+		{"ForwardedTakesPrecedence", "10.0.0.2:5000", map[string]string{"Forwarded": "for=198.51.100.4", "X-Forwarded-For": "1.1.1.1"}, "198.51.100.4"},
+		{"ObfuscatedFallsBack", "10.0.0.2:5000", map[string]string{"Forwarded": "for=_hidden", "X-Real-IP": "198.51.100.4"}, "198.51.100.4"},
+		{"NoHeaders", "10.0.0.2:5000", nil, "10.0.0.2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			require.Equal(t, tt.expected, extractor(req))
+		})
+	}
+
+	_, err = NewIPExtractor([]string{"not-an-ip"})
+	require.Error(t, err)
+}
@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+
+	"github.com/AliIsmoilov/golang_monolight/config"
+	"github.com/AliIsmoilov/golang_monolight/internal/models"
+	"github.com/AliIsmoilov/golang_monolight/pkg/httpErrors"
+)
+
+// JWT Claims struct, Id (jti) holds the session id
+type Claims struct {
+	Email string `json:"email"`
+	ID    string `json:"id"`
+	jwt.StandardClaims
+}
+
+// Generate new JWT access token for user session
+func GenerateJWTToken(user *models.User, sessionID uuid.UUID, cfg *config.Config) (string, time.Time, error) {
+	expiresAt := time.Now().Add(time.Second * cfg.Server.AccessTokenExpire)
+
+	claims := &Claims{
+		Email: user.Email,
+		ID:    user.ID.String(),
+		StandardClaims: jwt.StandardClaims{
+			Id:        sessionID.String(),
+			IssuedAt:  time.Now().Unix(),
+			ExpiresAt: expiresAt.Unix(),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	tokenString, err := token.SignedString([]byte(cfg.Server.JwtSecretKey))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return tokenString, expiresAt, nil
+}
+
+// Parse and validate JWT access token
+func ParseJWTToken(tokenString string, cfg *config.Config) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+		}
+		return []byte(cfg.Server.JwtSecretKey), nil
+	})
+	if err != nil {
+		return nil, httpErrors.InvalidJWTToken
+	}
+
+	if !token.Valid {
+		return nil, httpErrors.InvalidJWTToken
+	}
+
+	if _, err = uuid.Parse(claims.ID); err != nil {
+		return nil, httpErrors.InvalidJWTClaims
+	}
+	if _, err = uuid.Parse(claims.Id); err != nil {
+		return nil, httpErrors.InvalidJWTClaims
+	}
+
+	return claims, nil
+}
+
+// Generate new opaque refresh token
+func GenerateRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Hash refresh token before storing it, only the hash is kept in the database
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
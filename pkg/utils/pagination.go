@@ -3,23 +3,42 @@ package utils
 import (
 	"fmt"
 	"math"
+	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/AliIsmoilov/golang_monolight/pkg/httpErrors"
 )
 
 const (
 	defaultSize = 10
+	maxSize     = 100
 )
 
+var sortFieldRegexp = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// Sort field of orderBy query param
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
 // Pagination query params
 type PaginationQuery struct {
 	Size    int    `json:"size,omitempty"`
 	Page    int    `json:"page,omitempty"`
 	OrderBy string `json:"orderBy,omitempty"`
+	Cursor  string `json:"cursor,omitempty"`
+
+	sort       []SortField
+	cursor     *Cursor
+	cursorMode bool
 }
 
-// Set page size
+// Set page size, size must be positive and is capped at maxSize
 func (q *PaginationQuery) SetSize(sizeQuery string) error {
 	if sizeQuery == "" {
 		q.Size = defaultSize
@@ -27,7 +46,13 @@ func (q *PaginationQuery) SetSize(sizeQuery string) error {
 	}
 	n, err := strconv.Atoi(sizeQuery)
 	if err != nil {
-		return err
+		return errors.Wrapf(httpErrors.BadQueryParams, "size: %s", sizeQuery)
+	}
+	if n < 1 {
+		return errors.Wrapf(httpErrors.BadQueryParams, "size: %d", n)
+	}
+	if n > maxSize {
+		n = maxSize
 	}
 	q.Size = n
 
@@ -49,9 +74,55 @@ func (q *PaginationQuery) SetPage(pageQuery string) error {
 	return nil
 }
 
-// Set order by
-func (q *PaginationQuery) SetOrderBy(orderByQuery string) {
-	q.OrderBy = orderByQuery
+// Set order by, comma separated fields, "-" prefix means descending order: "-created_at,title"
+func (q *PaginationQuery) SetOrderBy(orderByQuery string) error {
+	sort := make([]SortField, 0)
+	normalized := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(orderByQuery, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		field := SortField{Field: strings.TrimPrefix(part, "-"), Desc: strings.HasPrefix(part, "-")}
+		field.Field = strings.TrimPrefix(field.Field, "+")
+		if !sortFieldRegexp.MatchString(field.Field) || seen[field.Field] {
+			return errors.Wrapf(httpErrors.BadQueryParams, "orderBy: %s", part)
+		}
+		seen[field.Field] = true
+
+		sort = append(sort, field)
+		if field.Desc {
+			normalized = append(normalized, "-"+field.Field)
+		} else {
+			normalized = append(normalized, field.Field)
+		}
+	}
+
+	q.sort = sort
+	q.OrderBy = strings.Join(normalized, ",")
+
+	return nil
+}
+
+// Set cursor, switches query to keyset pagination mode, empty cursor means first page
+func (q *PaginationQuery) SetCursor(cursorQuery string) error {
+	q.cursorMode = true
+	q.Cursor = cursorQuery
+	q.cursor = nil
+	if cursorQuery == "" {
+		return nil
+	}
+
+	cursor, err := DecodeCursor(cursorQuery)
+	if err != nil {
+		return err
+	}
+	q.cursor = cursor
+
+	return nil
 }
 
 // Get offset
@@ -72,6 +143,63 @@ func (q *PaginationQuery) GetOrderBy() string {
 	return q.OrderBy
 }
 
+// Get sort fields
+func (q *PaginationQuery) GetSort() []SortField {
+	return q.sort
+}
+
+// Is keyset pagination mode
+func (q *PaginationQuery) IsCursorMode() bool {
+	return q.cursorMode
+}
+
+// Get decoded cursor, nil for the first page
+func (q *PaginationQuery) GetCursor() *Cursor {
+	return q.cursor
+}
+
+// Get keyset sort direction, keyset pagination supports sorting only by given field
+func (q *PaginationQuery) GetKeysetDesc(field string) (bool, error) {
+	switch {
+	case len(q.sort) == 0:
+		return false, nil
+	case len(q.sort) == 1 && q.sort[0].Field == field:
+		return q.sort[0].Desc, nil
+	default:
+		return false, errors.Wrapf(httpErrors.BadQueryParams, "orderBy: cursor pagination supports only %s", field)
+	}
+}
+
+// Get ORDER BY clause, sort fields are checked against whitelist of sortable fields mapped to columns.
+// Default sort is used when orderBy is empty, id is always the last tiebreaker so pages are stable.
+func (q *PaginationQuery) GetOrderByClause(columns map[string]string, defaultSort ...SortField) (string, error) {
+	sort := q.sort
+	if len(sort) == 0 {
+		sort = defaultSort
+	}
+
+	clause := make([]string, 0, len(sort)+1)
+	hasID := false
+	for _, field := range sort {
+		column, ok := columns[field.Field]
+		if !ok {
+			return "", errors.Wrapf(httpErrors.BadQueryParams, "orderBy: unknown field %s", field.Field)
+		}
+		hasID = hasID || column == "id"
+
+		if field.Desc {
+			clause = append(clause, column+" DESC")
+		} else {
+			clause = append(clause, column+" ASC")
+		}
+	}
+	if !hasID {
+		clause = append(clause, "id ASC")
+	}
+
+	return strings.Join(clause, ", "), nil
+}
+
 // Get OrderBy
 func (q *PaginationQuery) GetPage() int {
 	return q.Page
@@ -82,7 +210,11 @@ func (q *PaginationQuery) GetSize() int {
 	return q.Size
 }
 
+// Get normalized query string, may be used to build next page links
 func (q *PaginationQuery) GetQueryString() string {
+	if q.cursorMode {
+		return fmt.Sprintf("cursor=%s&size=%v&orderBy=%s", q.Cursor, q.GetSize(), q.GetOrderBy())
+	}
 	return fmt.Sprintf("page=%v&size=%v&orderBy=%s", q.GetPage(), q.GetSize(), q.GetOrderBy())
 }
 
@@ -95,7 +227,14 @@ func GetPaginationFromCtx(c echo.Context) (*PaginationQuery, error) {
 	if err := q.SetSize(c.QueryParam("size")); err != nil {
 		return nil, err
 	}
-	q.SetOrderBy(c.QueryParam("orderBy"))
+	if err := q.SetOrderBy(c.QueryParam("orderBy")); err != nil {
+		return nil, err
+	}
+	if c.QueryParams().Has("cursor") {
+		if err := q.SetCursor(c.QueryParam("cursor")); err != nil {
+			return nil, err
+		}
+	}
 
 	return q, nil
 }
@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AliIsmoilov/golang_monolight/pkg/httpErrors"
+)
+
+func TestPaginationQuery_OrderBy(t *testing.T) {
+	t.Parallel()
+
+	columns := map[string]string{"id": "id", "title": "title", "created_at": "created_at"}
+
+	t.Run("Normalized", func(t *testing.T) {
+		q := &PaginationQuery{Page: 2, Size: 10}
+		require.NoError(t, q.SetOrderBy(" -created_at, +title ,"))
+		require.Equal(t, "-created_at,title", q.GetOrderBy())
+		require.Equal(t, "page=2&size=10&orderBy=-created_at,title", q.GetQueryString())
+
+		orderBy, err := q.GetOrderByClause(columns)
+		require.NoError(t, err)
+		require.Equal(t, "created_at DESC, title ASC, id ASC", orderBy)
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		q := &PaginationQuery{}
+		require.NoError(t, q.SetOrderBy(""))
+
+		orderBy, err := q.GetOrderByClause(columns, SortField{Field: "created_at"})
+		require.NoError(t, err)
+		require.Equal(t, "created_at ASC, id ASC", orderBy)
+	})
+
+	t.Run("Unknown field", func(t *testing.T) {
+		q := &PaginationQuery{}
+		require.NoError(t, q.SetOrderBy("description"))
+
+		_, err := q.GetOrderByClause(columns)
+		require.ErrorIs(t, err, httpErrors.BadQueryParams)
+	})
+
+	t.Run("Invalid syntax", func(t *testing.T) {
+		q := &PaginationQuery{}
+		require.ErrorIs(t, q.SetOrderBy("title;DROP TABLE news"), httpErrors.BadQueryParams)
+		require.ErrorIs(t, q.SetOrderBy("title,-title"), httpErrors.BadQueryParams)
+	})
+}
+
+func TestPaginationQuery_Cursor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Round trip", func(t *testing.T) {
+		createdAt := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+		id := uuid.New()
+
+		q := &PaginationQuery{Size: 10}
+		require.NoError(t, q.SetCursor(EncodeCursor(createdAt, id)))
+		require.True(t, q.IsCursorMode())
+		require.True(t, createdAt.Equal(q.GetCursor().CreatedAt))
+		require.Equal(t, id, q.GetCursor().ID)
+	})
+
+	t.Run("First page", func(t *testing.T) {
+		q := &PaginationQuery{}
+		require.NoError(t, q.SetCursor(""))
+		require.True(t, q.IsCursorMode())
+		require.Nil(t, q.GetCursor())
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		q := &PaginationQuery{}
+		require.ErrorIs(t, q.SetCursor("bad cursor"), httpErrors.BadQueryParams)
+	})
+
+	t.Run("Sort", func(t *testing.T) {
+		q := &PaginationQuery{}
+		require.NoError(t, q.SetOrderBy("-created_at"))
+		desc, err := q.GetKeysetDesc("created_at")
+		require.NoError(t, err)
+		require.True(t, desc)
+
+		require.NoError(t, q.SetOrderBy("title"))
+		_, err = q.GetKeysetDesc("created_at")
+		require.ErrorIs(t, err, httpErrors.BadQueryParams)
+	})
+}
+
+func TestGetPaginationFromCtx_Size(t *testing.T) {
+	t.Parallel()
+
+	paginationFromURL := func(target string) (*PaginationQuery, error) {
+		c := echo.New().NewContext(httptest.NewRequest("GET", target, nil), httptest.NewRecorder())
+		return GetPaginationFromCtx(c)
+	}
+
+	for _, size := range []string{"0", "-1", "ten"} {
+		_, err := paginationFromURL("/news/list?cursor=&size=" + size)
+		require.ErrorIs(t, err, httpErrors.BadQueryParams, size)
+	}
+
+	q, err := paginationFromURL("/news/list?cursor=&size=1000")
+	require.NoError(t, err)
+	require.True(t, q.IsCursorMode())
+	require.Equal(t, maxSize, q.GetSize())
+
+	q, err = paginationFromURL("/news/list?cursor=")
+	require.NoError(t, err)
+	require.Equal(t, defaultSize, q.GetSize())
+}